@@ -0,0 +1,14 @@
+// See examples from https://docs.exasol.com/sql/create_consumer_group.htm
+
+resource "exasol_consumer_group" "analytics" {
+  name                      = "analytics"
+  precedence                = 800
+  cpu_weight                = 300
+  group_temp_db_ram_limit   = "200G"
+  session_temp_db_ram_limit = "20G"
+}
+
+resource "exasol_role" "analyst" {
+  name           = "analyst"
+  consumer_group = exasol_consumer_group.analytics.name
+}
@@ -0,0 +1,22 @@
+// See examples from https://docs.exasol.com/sql/create_function.htm
+
+resource "exasol_function" "percentage" {
+  schema = exasol_physical_schema.my_schema.name
+  name   = "percentage"
+  parameter {
+    name = "fraction"
+    type = "DECIMAL"
+  }
+  parameter {
+    name = "entirety"
+    type = "DECIMAL"
+  }
+  returns = "VARCHAR(10)"
+  body    = <<-EOT
+    res DECIMAL;
+    BEGIN
+      res := (100*fraction)/entirety;
+      RETURN res || ' %';
+    END percentage;
+  EOT
+}
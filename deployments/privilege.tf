@@ -0,0 +1,37 @@
+// See examples from https://docs.exasol.com/sql/grant.htm
+
+resource "exasol_system_privilege" "user_1_session" {
+  grantee   = exasol_user.user_1.name
+  privilege = "CREATE SESSION"
+}
+
+resource "exasol_system_privilege" "test_role_table" {
+  grantee           = exasol_role.test_role.name
+  privilege         = "CREATE TABLE"
+  with_admin_option = true
+}
+
+resource "exasol_object_privilege" "test_role_select" {
+  grantee     = exasol_role.test_role.name
+  privilege   = "SELECT"
+  object_type = "TABLE"
+  object      = exasol_table.t1.id
+}
+
+resource "exasol_object_privilege" "user_1_schema" {
+  grantee     = exasol_user.user_1.name
+  privilege   = "SELECT"
+  object_type = "SCHEMA"
+  object      = exasol_physical_schema.my_schema.name
+}
+
+// Revokes every privilege of the Role not listed here
+resource "exasol_grantee_privileges" "reader" {
+  grantee           = exasol_role.reader.name
+  system_privileges = ["CREATE SESSION"]
+  object_privileges {
+    privilege   = "SELECT"
+    object_type = "SCHEMA"
+    object      = exasol_physical_schema.my_schema.name
+  }
+}
@@ -2,3 +2,18 @@
 resource "exasol_role" "test_role" {
    name = "test_role"
 }
+
+resource "exasol_role" "reader" {
+   name = "reader"
+}
+
+resource "exasol_role_grant" "test_role_reader" {
+   role              = exasol_role.reader.name
+   grantee           = exasol_role.test_role.name
+   with_admin_option = true
+}
+
+resource "exasol_role_grant" "user_1_reader" {
+   role    = exasol_role.reader.name
+   grantee = exasol_user.user_1.name
+}
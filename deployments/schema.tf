@@ -2,10 +2,12 @@
 // See examples from https://docs.exasol.com/sql/create_schema.htm
 
 resource "exasol_physical_schema" "my_schema" {
-  name = "my_schema"
+  name           = "my_schema"
+  owner          = exasol_role.test_role.name
+  comment        = "Data of my team"
+  raw_size_limit = 1073741824
 }
 
-/*
 resource "exasol_virtual_schema" "hive" {
   name = "hive"
   adapter_script = "adapter.jdbc_adapter"
@@ -16,4 +18,4 @@ resource "exasol_virtual_schema" "hive" {
     USERNAME	      = "hive-usr"
     PASSWORD	      = "hive-pwd"
   }
-*/
+}
@@ -0,0 +1,17 @@
+// See examples from https://docs.exasol.com/sql/create_script.htm
+
+resource "exasol_script" "double_it" {
+  schema   = exasol_physical_schema.my_schema.name
+  name     = "double_it"
+  language = "PYTHON3"
+  kind     = "SCALAR"
+  input {
+    name = "x"
+    type = "DOUBLE"
+  }
+  returns = "DOUBLE"
+  body    = <<-EOT
+    def run(ctx):
+        return ctx.x * 2
+  EOT
+}
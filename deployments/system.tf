@@ -0,0 +1,17 @@
+// See examples from https://docs.exasol.com/sql/alter_system.htm
+
+resource "exasol_system_parameter" "query_timeout" {
+  name  = "QUERY_TIMEOUT"
+  value = "3600"
+}
+
+resource "exasol_system_parameter" "password_policy" {
+  name  = "PASSWORD_SECURITY_POLICY"
+  value = "MIN_LENGTH=12:MIN_NUMERIC_CHARS=1"
+}
+
+// Adds one alias to SCRIPT_LANGUAGES and keeps all others
+resource "exasol_script_language" "python3_custom" {
+  alias = "PYTHON3_CUSTOM"
+  url   = "localzmq+protobuf:///bfsdefault/default/python3_custom?lang=python#buckets/bfsdefault/default/python3_custom/exaudf/exaudfclient_py3"
+}
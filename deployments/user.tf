@@ -9,3 +9,17 @@ resource "exasol_user" "user_2" {
     ldap = "cn=user_2,dc=authorization,dc=exasol,dc=com"
 }
 */
+
+/* Only works when Kerberos is configured
+resource "exasol_user" "user_3" {
+    name = "user_3"
+    kerberos = "user_3@EXAMPLE.COM"
+}
+*/
+
+/* Only works when OpenID is configured
+resource "exasol_user" "user_4" {
+    name = "user_4"
+    openid_subject = "user_4@example.com"
+}
+*/
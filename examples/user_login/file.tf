@@ -1,3 +0,0 @@
-data "local_file" "login" {
-    filename = "${path.module}/.exasol_auth.json"
-}
@@ -1,4 +1,3 @@
 provider "exasol" {
-  username = jsondecode(data.local_file.login.content).username
-  password = jsondecode(data.local_file.login.content).password
+  credentials_file = "${path.module}/.exasol_auth.json"
 }
@@ -5,7 +5,7 @@ go 1.16
 require (
 	github.com/andreyvit/diff v0.0.0-20170406064948-c7f18ee00883
 	github.com/google/go-cmp v0.5.5
-	github.com/gorilla/websocket v1.4.2 // indirect
+	github.com/gorilla/websocket v1.4.2
 	github.com/grantstreetgroup/go-exasol-client v0.0.0-20210611152946-64b3fce36d4a
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.6.1
 )
@@ -0,0 +1,233 @@
+// Package credentials reads connection settings for Exasol
+// from JSON or INI profile files.
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultProfile is used when no profile is selected
+	DefaultProfile = "default"
+)
+
+// Profile holds the connection settings of one named profile
+type Profile struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	Fingerprint string
+}
+
+// Error describes why a credentials file is invalid
+type Error struct {
+	File   string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid credentials file %s: %s", e.File, e.Reason)
+}
+
+// ReadProfile reads the named profile from a JSON or INI file
+func ReadProfile(file, profile string) (Profile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return Profile{}, &Error{File: file, Reason: err.Error()}
+	}
+
+	profiles, err := Parse(file, content)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return SelectProfile(file, profiles, profile)
+}
+
+// SelectProfile picks the named profile
+func SelectProfile(file string, profiles map[string]Profile, profile string) (Profile, error) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	p, ok := profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return Profile{}, &Error{
+			File:   file,
+			Reason: fmt.Sprintf("profile %s not found (available: %s)", profile, strings.Join(names, ", ")),
+		}
+	}
+	return p, nil
+}
+
+// Parse parses all profiles. JSON is detected by file extension or
+// by content starting with `{`. Everything else is parsed as INI.
+func Parse(file string, content []byte) (map[string]Profile, error) {
+	ext := strings.ToLower(filepath.Ext(file))
+	trimmed := bytes.TrimSpace(content)
+	if ext == ".json" || (ext != ".ini" && bytes.HasPrefix(trimmed, []byte("{"))) {
+		return parseJSON(file, content)
+	}
+	return parseINI(file, content)
+}
+
+// parseJSON parses either a single flat profile or an object of
+// named profiles
+func parseJSON(file string, content []byte) (map[string]Profile, error) {
+	raw := map[string]interface{}{}
+	err := json.Unmarshal(content, &raw)
+	if err != nil {
+		return nil, &Error{File: file, Reason: err.Error()}
+	}
+
+	nested := false
+	for _, v := range raw {
+		if _, ok := v.(map[string]interface{}); ok {
+			nested = true
+			break
+		}
+	}
+
+	if !nested {
+		p, err := profileFromMap(file, DefaultProfile, raw)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]Profile{DefaultProfile: p}, nil
+	}
+
+	profiles := make(map[string]Profile, len(raw))
+	for name, v := range raw {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, &Error{File: file, Reason: fmt.Sprintf("profile %s is not an object", name)}
+		}
+		p, err := profileFromMap(file, name, m)
+		if err != nil {
+			return nil, err
+		}
+		profiles[name] = p
+	}
+	return profiles, nil
+}
+
+func parseINI(file string, content []byte) (map[string]Profile, error) {
+	sections := map[string]map[string]interface{}{}
+	section := DefaultProfile
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, &Error{File: file, Reason: fmt.Sprintf("line %d: unterminated section", lineNumber)}
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[section]; !ok {
+				sections[section] = map[string]interface{}{}
+			}
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, &Error{File: file, Reason: fmt.Sprintf("line %d: expected key = value", lineNumber)}
+		}
+		if _, ok := sections[section]; !ok {
+			sections[section] = map[string]interface{}{}
+		}
+		value := strings.TrimSpace(parts[1])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		sections[section][strings.TrimSpace(parts[0])] = value
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, &Error{File: file, Reason: err.Error()}
+	}
+
+	profiles := make(map[string]Profile, len(sections))
+	for name, m := range sections {
+		p, err := profileFromMap(file, name, m)
+		if err != nil {
+			return nil, err
+		}
+		profiles[name] = p
+	}
+	return profiles, nil
+}
+
+func profileFromMap(file, name string, m map[string]interface{}) (Profile, error) {
+	p := Profile{}
+	invalid := func(reason string) error {
+		return &Error{File: file, Reason: fmt.Sprintf("profile %s: %s", name, reason)}
+	}
+
+	for k, v := range m {
+		switch k {
+		case "host", "username", "password", "fingerprint":
+			s, ok := v.(string)
+			if !ok {
+				return Profile{}, invalid(fmt.Sprintf("%s has to be a string", k))
+			}
+			switch k {
+			case "host":
+				p.Host = s
+			case "username":
+				p.Username = s
+			case "password":
+				p.Password = s
+			case "fingerprint":
+				p.Fingerprint = s
+			}
+		case "port":
+			port, err := parsePort(v)
+			if err != nil {
+				return Profile{}, invalid(err.Error())
+			}
+			p.Port = port
+		default:
+			return Profile{}, invalid(fmt.Sprintf("unknown key %s", k))
+		}
+	}
+	return p, nil
+}
+
+func parsePort(v interface{}) (int, error) {
+	var port int
+	switch t := v.(type) {
+	case float64:
+		port = int(t)
+		if float64(port) != t {
+			return 0, fmt.Errorf("port %v is not an integer", t)
+		}
+	case string:
+		var err error
+		port, err = strconv.Atoi(t)
+		if err != nil {
+			return 0, fmt.Errorf("port %s is not an integer", t)
+		}
+	default:
+		return 0, fmt.Errorf("port has to be a number")
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
@@ -0,0 +1,142 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected map[string]Profile
+	}{
+		{
+			name: "flat json",
+			file: ".exasol_auth.json",
+			content: `{
+				"username": "sys",
+				"password": "exasol"
+			}`,
+			expected: map[string]Profile{
+				"default": {Username: "sys", Password: "exasol"},
+			},
+		},
+		{
+			name: "profiles json",
+			file: "credentials",
+			content: `{
+				"default": {"host": "127.0.0.1", "port": 8563},
+				"prod": {"host": "exa.example.com", "port": "8564", "username": "ci", "fingerprint": "ABCD"}
+			}`,
+			expected: map[string]Profile{
+				"default": {Host: "127.0.0.1", Port: 8563},
+				"prod":    {Host: "exa.example.com", Port: 8564, Username: "ci", Fingerprint: "ABCD"},
+			},
+		},
+		{
+			name: "ini",
+			file: "credentials.ini",
+			content: `
+# global values belong to default
+username = sys
+
+[default]
+password = "ex=asol"
+
+; production cluster
+[prod]
+host = exa.example.com
+port = 8564
+`,
+			expected: map[string]Profile{
+				"default": {Username: "sys", Password: "ex=asol"},
+				"prod":    {Host: "exa.example.com", Port: 8564},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		profiles, err := Parse(tc.file, []byte(tc.content))
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %s", tc.name, err)
+		}
+		d := cmp.Diff(profiles, tc.expected)
+		if d != "" {
+			t.Fatalf("%s: Unexpected profiles:\n%s", tc.name, d)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		content string
+		reason  string
+	}{
+		{`{"username": 1}`, "username has to be a string"},
+		{`{"user": "sys"}`, "unknown key user"},
+		{`{"port": 70000}`, "port 70000 out of range"},
+		{`{"port": 1.5}`, "port 1.5 is not an integer"},
+		{`{"prod": {"host": "a"}, "other": "b"}`, "profile other is not an object"},
+		{"[prod\nhost = a", "line 1: unterminated section"},
+		{"host", "line 1: expected key = value"},
+		{"port = http", "port http is not an integer"},
+	}
+
+	for _, tc := range tests {
+		_, err := Parse("credentials", []byte(tc.content))
+		var ce *Error
+		if !errors.As(err, &ce) {
+			t.Fatalf("Expected Error for %s: %#v", tc.content, err)
+		}
+		if !strings.Contains(ce.Reason, tc.reason) {
+			t.Fatalf("Expected reason %s: %s", tc.reason, ce.Reason)
+		}
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "credentials.ini")
+	err := os.WriteFile(file, []byte("[default]\nhost = a\n[prod]\nhost = b\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ReadProfile(file, "")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if p.Host != "a" {
+		t.Fatalf("Expected host a: %s", p.Host)
+	}
+
+	p, err = ReadProfile(file, "prod")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if p.Host != "b" {
+		t.Fatalf("Expected host b: %s", p.Host)
+	}
+
+	_, err = ReadProfile(file, "staging")
+	if err == nil || !strings.Contains(err.Error(), "profile staging not found (available: default, prod)") {
+		t.Fatalf("Expected missing profile error: %v", err)
+	}
+
+	_, err = ReadProfile(filepath.Join(t.TempDir(), "missing"), "")
+	if err == nil {
+		t.Fatal("Expected error for missing file")
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
-	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,12 +25,15 @@ func PhysicalSchema() *schema.Resource {
 
 func readPhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	return readPhysicalSchemaData(d, locked.Conn)
 }
 
-func readPhysicalSchemaData(d internal.Data, c *exasol.Conn) diag.Diagnostics {
+func readPhysicalSchemaData(d internal.Data, c internal.Conn) diag.Diagnostics {
 	name := d.Get("name").(string)
 
 	res, err := c.FetchSlice("SELECT SCHEMA_NAME FROM EXA_ALL_SCHEMAS WHERE UPPER(SCHEMA_NAME) = UPPER(?) AND SCHEMA_IS_VIRTUAL = FALSE ", []interface{}{
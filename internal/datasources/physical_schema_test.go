@@ -11,7 +11,7 @@ import (
 func TestReadPhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
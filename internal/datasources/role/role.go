@@ -6,7 +6,6 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
-	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -27,12 +26,15 @@ func Resource() *schema.Resource {
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	return readData(d, locked.Conn)
 }
 
-func readData(d internal.Data, c *exasol.Conn) diag.Diagnostics {
+func readData(d internal.Data, c internal.Conn) diag.Diagnostics {
 	name, err := argument.Name(d)
 	if err != nil {
 		return diag.FromErr(err)
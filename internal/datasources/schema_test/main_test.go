@@ -28,14 +28,14 @@ func testRun(m *testing.M) int {
 	exaClient = internal.MustCreateTestClient()
 
 	func() {
-		locked := exaClient.Lock()
+		locked := internal.MustLock(exaClient)
 		defer locked.Unlock()
 		locked.Conn.Execute(fmt.Sprintf("CREATE SCHEMA %s", schemaName))
 		db.MustCommit(locked.Conn)
 	}()
 
 	defer func() {
-		locked := exaClient.Lock()
+		locked := internal.MustLock(exaClient)
 		defer locked.Unlock()
 		locked.Conn.Execute(fmt.Sprintf("DROP SCHEMA %s CASCADE", schemaName))
 		locked.Conn.Commit()
@@ -4,13 +4,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/test"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccExasolSchema_basic(t *testing.T) {
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	ps := test.NewDefaultAccProviders()
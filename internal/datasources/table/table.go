@@ -8,7 +8,6 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
-	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -47,7 +46,10 @@ func Resource() *schema.Resource {
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
@@ -56,9 +58,9 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	return readData(d, locked.Conn, ra)
 }
 
-func readData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func readData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
-	tr, err := computed.ReadTable(c, args.Schema, args.Name)
+	tr, err := computed.ReadTable(c, args.Schema, args.Name, false)
 	if err != nil {
 		return diag.FromErr(err)
 	}
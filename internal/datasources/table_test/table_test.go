@@ -12,7 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/internal"
 )
 
 var (
@@ -269,7 +269,7 @@ type expectedColumns struct {
 
 // TestAccExasolTable_basic all examples provided by Exasol.
 func TestAccExasolTable_basic(t *testing.T) {
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	for i, v := range testDefs {
@@ -409,7 +409,7 @@ data "exasol_table" "%s" {
 	})
 }
 
-func basicSetup(t *testing.T, c *exasol.Conn) {
+func basicSetup(t *testing.T, c internal.Conn) {
 
 	for _, testDef := range testDefs {
 
@@ -425,7 +425,7 @@ func basicSetup(t *testing.T, c *exasol.Conn) {
 	c.Commit()
 }
 
-func tryDropTable(ref string, c *exasol.Conn) {
+func tryDropTable(ref string, c internal.Conn) {
 	stmt := fmt.Sprintf("DROP TABLE %s", ref)
 	c.Execute(stmt, nil, schemaName)
 }
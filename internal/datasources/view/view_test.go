@@ -15,7 +15,7 @@ func TestRead(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	_, err := locked.Conn.Execute(fmt.Sprintf("CREATE OR REPLACE TABLE %s_TABLE (A CHAR(10), B VARCHAR(20), C INT)", name), nil, schemaName)
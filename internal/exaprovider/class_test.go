@@ -0,0 +1,65 @@
+package exaprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grantstreetgroup/go-exasol-client"
+)
+
+func TestLockClassSerializes(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(exasol.ConnConf{})
+
+	release, err := c.LockClass(context.Background(), ObjectClassUser)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// Other classes are independent
+	releaseRole, err := c.LockClass(context.Background(), ObjectClassRole)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	releaseRole()
+
+	acquired := make(chan func())
+	go func() {
+		r, err := c.LockClass(context.Background(), ObjectClassUser)
+		if err != nil {
+			panic(err)
+		}
+		acquired <- r
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected LockClass to block while class is locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	second := <-acquired
+	second()
+}
+
+func TestLockClassHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(exasol.ConnConf{})
+	release, err := c.LockClass(context.Background(), ObjectClassConnection)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.LockClass(ctx, ObjectClassConnection)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline to be exceeded: %v", err)
+	}
+}
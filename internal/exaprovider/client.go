@@ -1,51 +1,493 @@
 package exaprovider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// DefaultMaxConnections is used when Options do not limit connections.
+	// Matches the default parallelism of Terraform.
+	DefaultMaxConnections = 10
+	// DefaultConnectRetryTimeout is used when Options do not set a
+	// retry timeout
+	DefaultConnectRetryTimeout = time.Minute
+	// DefaultMaxRetryAttempts is used when Options do not limit
+	// attempts of statements rolled back by Exasol
+	DefaultMaxRetryAttempts = 10
+	// DefaultMaxIdleTime is used when Options do not limit how long
+	// a connection may stay in the pool
+	DefaultMaxIdleTime = 5 * time.Minute
+
+	connectRetryInterval    = 500 * time.Millisecond
+	maxConnectRetryInterval = 5 * time.Second
+	// livenessTimeout bounds checking a pooled connection
+	livenessTimeout = 10 * time.Second
 )
 
 // Client implements everything that is needed to act as a Provider
 // including the actual client to Exasol Websocket
 type Client struct {
 	conf exasol.ConnConf
+	opts Options
+	// slots bounds the amount of connections handed out at once
+	slots chan struct{}
+	mux   sync.Mutex
+	idle  []*Conn
+	// closed stops pooling connections
+	closed bool
+	// classes serializes mutations per ObjectClass
+	classes map[ObjectClass]chan struct{}
+}
+
+// ObjectClass is a class of global objects. Parallel mutations
+// of one class collide in Exasol.
+type ObjectClass string
+
+const (
+	ObjectClassConnection    ObjectClass = "CONNECTION"
+	ObjectClassConsumerGroup ObjectClass = "CONSUMER GROUP"
+	ObjectClassPrivilege     ObjectClass = "PRIVILEGE"
+	ObjectClassRole          ObjectClass = "ROLE"
+	ObjectClassSystem        ObjectClass = "SYSTEM"
+	ObjectClassUser          ObjectClass = "USER"
+)
+
+// Options tune the behavior of a Client
+type Options struct {
+	// MaxConnections is the upper bound of open connections
+	MaxConnections int
+	// ConnectRetryTimeout is the time span in which transient
+	// connection failures are retried
+	ConnectRetryTimeout time.Duration
+	// MaxRetryAttempts bounds attempts of operations which Exasol
+	// rolled back due to transaction collisions
+	MaxRetryAttempts int
+	// AccessToken is an OpenID access token used instead of a password
+	AccessToken string
+	// RefreshToken is an OpenID refresh token used instead of a password
+	RefreshToken string
+	// SessionParameters are applied with ALTER SESSION on every
+	// new connection
+	SessionParameters map[string]string
+	// MaxIdleTime is the time span after which a pooled connection
+	// is closed instead of being reused
+	MaxIdleTime time.Duration
+}
+
+// ConnectError is returned when no connection to Exasol
+// could be established
+type ConnectError struct {
+	Host string
+	Port uint16
+	Err  error
+}
+
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("connecting to Exasol at %s:%d failed: %s", e.Host, e.Port, e.Err)
+}
+
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// ConnectDiagnostics converts an error from Lock into Diagnostics
+func ConnectDiagnostics(err error) diag.Diagnostics {
+	var ce *ConnectError
+	if !errors.As(err, &ce) {
+		return diag.FromErr(err)
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to connect to Exasol at %s:%d", ce.Host, ce.Port),
+			Detail:   ce.Err.Error(),
+		},
+	}
 }
 
 type Locked struct {
-	Conn *exasol.Conn
+	Conn   *Conn
+	client *Client
+	ctx    context.Context
+	// timeout is the query timeout set for the deadline of ctx
+	timeout bool
+	// stop ends watching ctx and is closed by Unlock
+	stop    chan struct{}
+	stopped chan struct{}
 }
 
 func NewClient(conf exasol.ConnConf) *Client {
+	return NewClientWithOptions(conf, Options{})
+}
+
+func NewClientWithOptions(conf exasol.ConnConf, opts Options) *Client {
+	if opts.MaxConnections < 1 {
+		opts.MaxConnections = DefaultMaxConnections
+	}
+	if opts.ConnectRetryTimeout <= 0 {
+		opts.ConnectRetryTimeout = DefaultConnectRetryTimeout
+	}
+	if opts.MaxRetryAttempts < 1 {
+		opts.MaxRetryAttempts = DefaultMaxRetryAttempts
+	}
+	if opts.MaxIdleTime <= 0 {
+		opts.MaxIdleTime = DefaultMaxIdleTime
+	}
+
 	c := &Client{
-		conf: conf,
+		conf:    conf,
+		opts:    opts,
+		slots:   make(chan struct{}, opts.MaxConnections),
+		classes: map[ObjectClass]chan struct{}{},
 	}
 
 	return c
 }
 
-func newConnect(conf exasol.ConnConf) *exasol.Conn {
+func newConnect(conf exasol.ConnConf, sessionParams map[string]string) (*exasol.Conn, error) {
 	conn, err := exasol.Connect(conf)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	err = applySessionParameters(conn, sessionParams)
+	if err != nil {
+		conn.Disconnect()
+		return nil, err
+	}
+	err = conn.DisableAutoCommit()
+	if err != nil {
+		conn.Disconnect()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect establishes a new connection and retries transient
+// failures until ConnectRetryTimeout is exceeded or ctx is done
+func (c *Client) connect(ctx context.Context) (*Conn, error) {
+	deadline := time.Now().Add(c.opts.ConnectRetryTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	wait := connectRetryInterval
+	for {
+		conf := c.conf
+		var ws *wsHandler
+		if conf.WSHandler == nil {
+			ws = newWSHandler(c.opts)
+			conf.WSHandler = ws
+		}
+		remaining := time.Until(deadline)
+		if remaining > 0 && (conf.ConnectTimeout == 0 || remaining < conf.ConnectTimeout) {
+			conf.ConnectTimeout = remaining
+		}
+		conn, err := newConnect(conf, c.opts.SessionParameters)
+		if err == nil {
+			return &Conn{
+				Conn: conn,
+				ws:   ws,
+			}, nil
+		}
+		if !isTransient(err) || time.Now().Add(wait).After(deadline) {
+			return nil, &ConnectError{
+				Host: c.conf.Host,
+				Port: c.conf.Port,
+				Err:  err,
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &ConnectError{
+				Host: c.conf.Host,
+				Port: c.conf.Port,
+				Err:  ctx.Err(),
+			}
+		case <-timer.C:
+		}
+		wait *= 2
+		if wait > maxConnectRetryInterval {
+			wait = maxConnectRetryInterval
+		}
 	}
-	conn.DisableAutoCommit()
-	return conn
 }
 
-func (c *Client) Lock() *Locked {
-	return &Locked{
-		Conn: newConnect(c.conf),
+// isTransient checks whether a connection failure happened on network
+// level. Failed logins and certificate mismatches are not transient.
+func isTransient(err error) bool {
+	var fe *FingerprintError
+	if errors.As(err, &fe) {
+		return false
 	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Lock hands out a connection for exclusive use.
+// Blocks until one of the connection slots is available.
+func (c *Client) Lock() (*Locked, error) {
+	return c.LockContext(context.Background())
+}
+
+// LockContext hands out a connection for exclusive use.
+// Blocks until one of the connection slots is available or ctx is done.
+// The deadline of ctx is set as query timeout on the connection and
+// a running statement is aborted once ctx is done.
+func (c *Client) LockContext(ctx context.Context) (*Locked, error) {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	conn, err := c.takeIdle(ctx)
+	if err != nil {
+		<-c.slots
+		return nil, err
+	}
+	if conn == nil {
+		conn, err = c.connect(ctx)
+		if err != nil {
+			<-c.slots
+			return nil, err
+		}
+	}
+	l := &Locked{
+		Conn:   conn,
+		client: c,
+		ctx:    ctx,
+	}
+	err = l.setDeadline()
+	if errors.Is(err, context.DeadlineExceeded) {
+		// Nothing ran on conn so it stays usable
+		c.putIdle(conn)
+		<-c.slots
+		return nil, err
+	}
+	if err != nil {
+		l.Unlock()
+		return nil, err
+	}
+	l.watch()
+	return l, nil
+}
+
+// Verify opens a connection to check login and session parameters
+// up front. The connection is pooled afterwards.
+func (c *Client) Verify(ctx context.Context) error {
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return err
+	}
+	locked.Unlock()
+	return nil
+}
+
+// LockClass queues mutations of class within the provider instead
+// of letting them collide in Exasol. Blocks until no other mutation
+// of class is running or ctx is done. Returns the function to
+// release class again.
+func (c *Client) LockClass(ctx context.Context, class ObjectClass) (func(), error) {
+	c.mux.Lock()
+	ch, ok := c.classes[class]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		c.classes[class] = ch
+	}
+	c.mux.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-ch
+	}, nil
+}
+
+// MaxRetryAttempts is the upper bound of attempts for operations
+// rolled back due to transaction collisions
+func (c *Client) MaxRetryAttempts() int {
+	return c.opts.MaxRetryAttempts
+}
+
+// takeIdle returns a pooled connection which is still usable
+// or nil if there is none
+func (c *Client) takeIdle(ctx context.Context) (*Conn, error) {
+	for {
+		err := expired(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mux.Lock()
+		n := len(c.idle)
+		if n == 0 {
+			c.mux.Unlock()
+			return nil, nil
+		}
+		conn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mux.Unlock()
+
+		if time.Since(conn.idleSince) <= c.opts.MaxIdleTime && isReusable(ctx, conn) {
+			return conn, nil
+		}
+		conn.Disconnect()
+	}
+}
+
+// isReusable checks whether the connection is alive and
+// does not carry over an open transaction. A connection silently
+// dropped by the network fails the check once ctx is done or
+// livenessTimeout is exceeded.
+func isReusable(ctx context.Context, conn *Conn) bool {
+	var attr *exasol.Attributes
+	check := func() error {
+		var err error
+		attr, err = conn.GetSessionAttr()
+		return err
+	}
+	var err error
+	if conn.ws == nil {
+		err = check()
+	} else {
+		err = conn.ws.limit(ctx, livenessTimeout, check)
+	}
+	if err != nil || attr == nil {
+		return false
+	}
+	return attr.OpenTransaction == 0
+}
+
+func (c *Client) putIdle(conn *Conn) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.closed {
+		conn.Disconnect()
+		return
+	}
+	conn.idleSince = time.Now()
+	c.idle = append(c.idle, conn)
+}
+
+// Close disconnects all pooled connections. Connections handed
+// out are disconnected on Unlock.
+func (c *Client) Close() {
+	c.mux.Lock()
+	idle := c.idle
+	c.idle = nil
+	c.closed = true
+	c.mux.Unlock()
+
+	for _, conn := range idle {
+		conn.Disconnect()
+	}
+}
+
+// queryTimeout returns the query timeout configured
+// via session parameters
+func (c *Client) queryTimeout() string {
+	for name, value := range c.opts.SessionParameters {
+		if strings.EqualFold(name, "QUERY_TIMEOUT") {
+			return value
+		}
+	}
+	return "0"
+}
+
+// expired reports ctx as done once its deadline passed even if
+// ctx did not notice yet
+func expired(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if ok && time.Until(deadline) <= 0 {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// setDeadline limits statements to the remaining time of ctx
+func (l *Locked) setDeadline() error {
+	deadline, ok := l.ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return context.DeadlineExceeded
+	}
+	seconds := int64(math.Ceil(remaining.Seconds()))
+	_, err := l.Conn.Execute(fmt.Sprintf("ALTER SESSION SET QUERY_TIMEOUT = %d", seconds))
+	if err != nil {
+		return err
+	}
+	l.timeout = true
+	return nil
+}
+
+// watch aborts the running statement once ctx is done
+func (l *Locked) watch() {
+	if l.ctx.Done() == nil || l.Conn.ws == nil {
+		return
+	}
+	ws := l.Conn.ws
+	l.stop = make(chan struct{})
+	l.stopped = make(chan struct{})
+	go func() {
+		defer close(l.stopped)
+		select {
+		case <-l.ctx.Done():
+			ws.abort(l.ctx.Err())
+		case <-l.stop:
+		}
+	}()
 }
 
 func (l *Locked) Unlock() {
 	// Ensure that only explicitly committed operations stay
 	conn := l.Conn
+	c := l.client
 	l.Conn = nil
+	defer func() {
+		<-c.slots
+	}()
+	if l.stop != nil {
+		close(l.stop)
+		<-l.stopped
+	}
+	if l.ctx.Err() != nil {
+		// Statements might have been aborted. Disconnecting
+		// discards the transaction anyway.
+		conn.Disconnect()
+		return
+	}
 	err := conn.Rollback()
 	if err != nil {
 		fmt.Println("Rollback failed:", err)
+		conn.Disconnect()
+		return
+	}
+	if l.timeout {
+		_, err = conn.Execute(fmt.Sprintf("ALTER SESSION SET QUERY_TIMEOUT = %s", c.queryTimeout()))
+		if err != nil {
+			conn.Disconnect()
+			return
+		}
 	}
-	conn.Disconnect()
+	c.putIdle(conn)
 }
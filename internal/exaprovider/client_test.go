@@ -0,0 +1,227 @@
+package exaprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLockReusesConnection(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClient(s.conf())
+
+	for i := 0; i < 3; i++ {
+		locked := mustLock(c)
+		locked.Unlock()
+	}
+
+	if s.connectCount() != 1 {
+		t.Fatalf("Expected 1 connect: %d", s.connectCount())
+	}
+
+	rollbacks := 0
+	for _, stmt := range s.executed() {
+		if stmt == "ROLLBACK" {
+			rollbacks++
+		}
+	}
+	if rollbacks != 3 {
+		t.Fatalf("Expected rollback on every Unlock: %d", rollbacks)
+	}
+}
+
+func TestLockBoundsConnections(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClientWithOptions(s.conf(), Options{
+		MaxConnections: 2,
+	})
+
+	first := mustLock(c)
+	second := mustLock(c)
+
+	acquired := make(chan *Locked)
+	go func() {
+		acquired <- mustLock(c)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected Lock to block while all connections are handed out")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	first.Unlock()
+	third := <-acquired
+	third.Unlock()
+	second.Unlock()
+
+	if s.connectCount() != 2 {
+		t.Fatalf("Expected 2 connects: %d", s.connectCount())
+	}
+}
+
+func TestUnlockDiscardsConnectionOnFailedRollback(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClient(s.conf())
+
+	s.fail("ROLLBACK", standinException{
+		Text: "Rollback broken",
+	})
+	locked := mustLock(c)
+	locked.Unlock()
+
+	s.succeed("ROLLBACK")
+	locked = mustLock(c)
+	locked.Unlock()
+
+	if s.connectCount() != 2 {
+		t.Fatalf("Expected broken connection to be replaced: %d connects", s.connectCount())
+	}
+}
+
+func TestLockDiscardsConnectionIdleTooLong(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClientWithOptions(s.conf(), Options{
+		MaxIdleTime: time.Nanosecond,
+	})
+
+	for i := 0; i < 2; i++ {
+		locked := mustLock(c)
+		locked.Unlock()
+	}
+
+	if s.connectCount() != 2 {
+		t.Fatalf("Expected idle connection to be replaced: %d connects", s.connectCount())
+	}
+}
+
+func TestLockContextBoundsLivenessCheck(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClient(s.conf())
+	locked := mustLock(c)
+	locked.Unlock()
+
+	s.dropAttributes()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.LockContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline to be exceeded: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("Liveness check ignored deadline: %s", time.Since(start))
+	}
+}
+
+func TestCloseDisconnectsIdleConnections(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClient(s.conf())
+	locked := mustLock(c)
+	locked.Unlock()
+
+	c.Close()
+	locked = mustLock(c)
+	locked.Unlock()
+	locked = mustLock(c)
+	locked.Unlock()
+
+	if s.connectCount() != 3 {
+		t.Fatalf("Expected no pooling after Close: %d connects", s.connectCount())
+	}
+}
+
+func TestLockReturnsConnectError(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	conf := s.conf()
+	s.server.Close()
+
+	c := NewClientWithOptions(conf, Options{
+		MaxConnections:      1,
+		ConnectRetryTimeout: time.Second,
+	})
+	_, err := c.Lock()
+	if err == nil {
+		t.Fatal("Expected error for unreachable host")
+	}
+
+	var ce *ConnectError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Expected ConnectError: %#v", err)
+	}
+
+	diags := ConnectDiagnostics(err)
+	expected := fmt.Sprintf("Unable to connect to Exasol at %s:%d", conf.Host, conf.Port)
+	if len(diags) != 1 || diags[0].Summary != expected {
+		t.Fatalf("Unexpected diagnostics: %#v", diags)
+	}
+
+	// Slot has to be released again
+	for i := 0; i < 2; i++ {
+		_, err = c.Lock()
+		if err == nil {
+			t.Fatal("Expected error for unreachable host")
+		}
+	}
+}
+
+func TestLockDoesNotRetryFailedLogin(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	s.rejectLogin = true
+	c := NewClient(s.conf())
+
+	_, err := c.Lock()
+	if err == nil {
+		t.Fatal("Expected error for failed login")
+	}
+
+	if s.connectCount() != 1 {
+		t.Fatalf("Expected failed login not to be retried: %d connects", s.connectCount())
+	}
+}
+
+func TestServerErrorCarriesSQLState(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	s.fail("COMMIT", standinException{
+		Text:    "GlobalTransactionRollback msg: Transaction collision: automatic transaction rollback.",
+		Sqlcode: SQLStateTransactionCollision,
+	})
+	c := NewClient(s.conf())
+
+	locked := mustLock(c)
+	defer locked.Unlock()
+
+	_, err := locked.Conn.Execute("CREATE ROLE R")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	err = locked.Conn.Commit()
+	var se *ServerError
+	if !errors.As(err, &se) {
+		t.Fatalf("Expected ServerError: %#v", err)
+	}
+	if se.SQLState != SQLStateTransactionCollision {
+		t.Fatalf("Unexpected SQLSTATE: %s", se.SQLState)
+	}
+}
@@ -0,0 +1,79 @@
+package exaprovider
+
+import (
+	"time"
+
+	"github.com/grantstreetgroup/go-exasol-client"
+)
+
+const (
+	// SQLStateTransactionCollision is reported when Exasol rolls back
+	// a transaction because it collided with another one
+	SQLStateTransactionCollision = "40001"
+)
+
+// Conn is a connection to Exasol which reports exceptions
+// of the server as *ServerError
+type Conn struct {
+	*exasol.Conn
+	ws *wsHandler
+	// idleSince is the time the connection was put into the pool
+	idleSince time.Time
+}
+
+// ServerError is an exception raised by Exasol
+type ServerError struct {
+	SQLState string
+	Text     string
+	Err      error
+}
+
+func (e *ServerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ServerError) Unwrap() error {
+	return e.Err
+}
+
+// Execute runs a statement. See exasol.Conn.Execute for args.
+func (c *Conn) Execute(sql string, args ...interface{}) (int64, error) {
+	c.resetException()
+	rows, err := c.Conn.Execute(sql, args...)
+	return rows, c.wrap(err)
+}
+
+// FetchSlice runs a query and returns all rows. See exasol.Conn.FetchSlice for args.
+func (c *Conn) FetchSlice(sql string, args ...interface{}) ([][]interface{}, error) {
+	c.resetException()
+	res, err := c.Conn.FetchSlice(sql, args...)
+	return res, c.wrap(err)
+}
+
+func (c *Conn) Commit() error {
+	c.resetException()
+	return c.wrap(c.Conn.Commit())
+}
+
+func (c *Conn) Rollback() error {
+	c.resetException()
+	return c.wrap(c.Conn.Rollback())
+}
+
+func (c *Conn) resetException() {
+	if c.ws != nil {
+		c.ws.exception = nil
+	}
+}
+
+// wrap attaches the exception of the server to err
+func (c *Conn) wrap(err error) error {
+	if err == nil || c.ws == nil || c.ws.exception == nil {
+		return err
+	}
+	return &ServerError{
+		SQLState: c.ws.exception.SQLCode,
+		Text:     c.ws.exception.Text,
+		Err:      err,
+	}
+}
@@ -0,0 +1,118 @@
+package exaprovider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockContextAbortsStatement(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	s.hang("DROP TABLE T")
+	c := NewClient(s.conf())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = locked.Conn.Execute("DROP TABLE T")
+	if err == nil || !strings.Contains(err.Error(), "Query aborted") {
+		t.Fatalf("Expected aborted statement: %v", err)
+	}
+	_, err = locked.Conn.Execute("SELECT 1")
+	if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Fatalf("Expected statements to be refused after cancel: %v", err)
+	}
+	locked.Unlock()
+
+	if s.abortCount() != 1 {
+		t.Fatalf("Expected 1 abortQuery: %d", s.abortCount())
+	}
+
+	// Aborted connection must not be reused
+	locked = mustLock(c)
+	locked.Unlock()
+	if s.connectCount() != 2 {
+		t.Fatalf("Expected aborted connection to be replaced: %d connects", s.connectCount())
+	}
+}
+
+func TestLockContextSetsQueryTimeout(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClientWithOptions(s.conf(), Options{
+		SessionParameters: map[string]string{
+			"QUERY_TIMEOUT": "600",
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	locked.Unlock()
+
+	executed := s.executed()
+	expected := []string{
+		"ALTER SESSION SET QUERY_TIMEOUT = 600",
+		"ALTER SESSION SET QUERY_TIMEOUT = 90",
+		"ROLLBACK",
+		"ALTER SESSION SET QUERY_TIMEOUT = 600",
+	}
+	if strings.Join(executed, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Unexpected statements: %#v", executed)
+	}
+	if s.abortCount() != 0 {
+		t.Fatalf("Expected no abortQuery: %d", s.abortCount())
+	}
+}
+
+func TestLockContextWaitsForSlot(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClientWithOptions(s.conf(), Options{
+		MaxConnections: 1,
+	})
+	locked := mustLock(c)
+	defer locked.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.LockContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline to be exceeded: %v", err)
+	}
+}
+
+func TestLockContextKeepsConnectionAfterDeadline(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClient(s.conf())
+	locked := mustLock(c)
+	locked.Unlock()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err := c.LockContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline to be exceeded: %v", err)
+	}
+
+	locked = mustLock(c)
+	locked.Unlock()
+	if s.connectCount() != 1 {
+		t.Fatalf("Expected pooled connection to survive: %d connects", s.connectCount())
+	}
+}
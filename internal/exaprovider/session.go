@@ -0,0 +1,105 @@
+package exaprovider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/grantstreetgroup/go-exasol-client"
+)
+
+type parameterKind int
+
+const (
+	stringParameter parameterKind = iota
+	integerParameter
+	switchParameter
+)
+
+// sessionParameters lists the parameters that ALTER SESSION accepts
+var sessionParameters = map[string]parameterKind{
+	"CONSTRAINT_STATE_DEFAULT":      stringParameter,
+	"DEFAULT_LIKE_ESCAPE_CHARACTER": stringParameter,
+	"HASHTYPE_FORMAT":               stringParameter,
+	"IDLE_TIMEOUT":                  integerParameter,
+	"NICE":                          switchParameter,
+	"NLS_DATE_FORMAT":               stringParameter,
+	"NLS_DATE_LANGUAGE":             stringParameter,
+	"NLS_FIRST_DAY_OF_WEEK":         integerParameter,
+	"NLS_NUMERIC_CHARACTERS":        stringParameter,
+	"NLS_TIMESTAMP_FORMAT":          stringParameter,
+	"PROFILE":                       switchParameter,
+	"QUERY_CACHE":                   stringParameter,
+	"QUERY_TIMEOUT":                 integerParameter,
+	"SCRIPT_LANGUAGES":              stringParameter,
+	"SCRIPT_OUTPUT_ADDRESS":         stringParameter,
+	"SESSION_TEMP_DB_RAM_LIMIT":     stringParameter,
+	"SNAPSHOT_MODE":                 stringParameter,
+	"SQL_PREPROCESSOR_SCRIPT":       stringParameter,
+	"TIME_ZONE":                     stringParameter,
+	"TIME_ZONE_BEHAVIOR":            stringParameter,
+	"TIMESTAMP_ARITHMETIC_BEHAVIOR": stringParameter,
+}
+
+// ValidateSessionParameter checks whether name is a known session
+// parameter and value fits it
+func ValidateSessionParameter(name, value string) error {
+	kind, ok := sessionParameters[strings.ToUpper(name)]
+	if !ok {
+		return fmt.Errorf("unknown session parameter %s", name)
+	}
+	switch kind {
+	case integerParameter:
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			return fmt.Errorf("session parameter %s expects a non-negative integer: %s", name, value)
+		}
+	case switchParameter:
+		v := strings.ToUpper(value)
+		if v != "ON" && v != "OFF" {
+			return fmt.Errorf("session parameter %s expects ON or OFF: %s", name, value)
+		}
+	}
+	return nil
+}
+
+// sessionStatements renders ALTER SESSION statements in stable order
+func sessionStatements(params map[string]string) ([]string, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stmts := make([]string, 0, len(params))
+	for _, name := range names {
+		value := params[name]
+		err := ValidateSessionParameter(name, value)
+		if err != nil {
+			return nil, err
+		}
+		upper := strings.ToUpper(name)
+		if sessionParameters[upper] == integerParameter {
+			stmts = append(stmts, fmt.Sprintf("ALTER SESSION SET %s = %s", upper, value))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("ALTER SESSION SET %s = %s", upper, quote.Literal(value)))
+		}
+	}
+	return stmts, nil
+}
+
+func applySessionParameters(conn *exasol.Conn, params map[string]string) error {
+	stmts, err := sessionStatements(params)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		_, err = conn.Execute(stmt)
+		if err != nil {
+			return fmt.Errorf("applying session parameter failed: %s", err)
+		}
+	}
+	return nil
+}
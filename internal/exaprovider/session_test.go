@@ -0,0 +1,112 @@
+package exaprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateSessionParameter(t *testing.T) {
+	t.Parallel()
+
+	valid := [][2]string{
+		{"QUERY_TIMEOUT", "120"},
+		{"query_timeout", "0"},
+		{"PROFILE", "on"},
+		{"NLS_DATE_FORMAT", "DD.MM.YYYY"},
+		{"TIME_ZONE", "EUROPE/BERLIN"},
+	}
+	for _, p := range valid {
+		err := ValidateSessionParameter(p[0], p[1])
+		if err != nil {
+			t.Fatalf("Unexpected error for %s = %s: %s", p[0], p[1], err)
+		}
+	}
+
+	invalid := [][2]string{
+		{"QUERY_TIMEOUTS", "120"},
+		{"QUERY_TIMEOUT", "2m"},
+		{"QUERY_TIMEOUT", "-1"},
+		{"PROFILE", "yes"},
+	}
+	for _, p := range invalid {
+		err := ValidateSessionParameter(p[0], p[1])
+		if err == nil {
+			t.Fatalf("Expected error for %s = %s", p[0], p[1])
+		}
+	}
+}
+
+func TestSessionParametersOnConnect(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	c := NewClientWithOptions(s.conf(), Options{
+		SessionParameters: map[string]string{
+			"query_timeout":   "120",
+			"NLS_DATE_FORMAT": "DD.MM.'YYYY'",
+		},
+	})
+	locked := mustLock(c)
+	locked.Unlock()
+
+	expected := []string{
+		"ALTER SESSION SET NLS_DATE_FORMAT = 'DD.MM.''YYYY'''",
+		"ALTER SESSION SET QUERY_TIMEOUT = 120",
+		"ROLLBACK",
+	}
+	d := cmp.Diff(s.executed(), expected)
+	if d != "" {
+		t.Fatalf("Unexpected statements:\n%s", d)
+	}
+}
+
+func TestSessionParametersFailing(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	s.fail("ALTER SESSION SET TIME_ZONE = 'MARS'", standinException{
+		Text:    "invalid time zone",
+		Sqlcode: "22023",
+	})
+	c := NewClientWithOptions(s.conf(), Options{
+		SessionParameters: map[string]string{
+			"TIME_ZONE": "MARS",
+		},
+	})
+	_, err := c.Lock()
+	if err == nil {
+		t.Fatal("Expected error for invalid session parameter")
+	}
+}
+
+func TestVerifyReportsSessionParameters(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	s.fail("ALTER SESSION SET NLS_DATE_FORMAT = 'NOT A FORMAT'", standinException{
+		Text:    "invalid date format",
+		Sqlcode: "22007",
+	})
+	c := NewClientWithOptions(s.conf(), Options{
+		SessionParameters: map[string]string{
+			"NLS_DATE_FORMAT": "NOT A FORMAT",
+		},
+	})
+	err := c.Verify(context.Background())
+	if err == nil {
+		t.Fatal("Expected error for invalid session parameter")
+	}
+
+	s.succeed("ALTER SESSION SET NLS_DATE_FORMAT = 'NOT A FORMAT'")
+	err = c.Verify(context.Background())
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	locked := mustLock(c)
+	locked.Unlock()
+	if s.connectCount() != 2 {
+		t.Fatalf("Expected verified connection to be reused: %d connects", s.connectCount())
+	}
+}
@@ -0,0 +1,297 @@
+package exaprovider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/grantstreetgroup/go-exasol-client"
+)
+
+// standin is a minimal local stand-in for the Exasol Websocket API.
+// It answers just enough of the protocol for connecting, executing
+// and disconnecting.
+type standin struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mux        sync.Mutex
+	connects   int
+	statements []string
+	// logins records the login command and authentication request
+	// of every connection
+	logins []map[string]interface{}
+	// failing maps SQL text to the exception returned for it
+	failing map[string]standinException
+	// hanging lists SQL texts which only return once aborted
+	hanging map[string]bool
+	aborts  int
+	// rejectLogin lets every authentication fail
+	rejectLogin bool
+	// silent drops getAttributes like a dead network would
+	silent bool
+}
+
+type standinException struct {
+	Text    string `json:"text"`
+	Sqlcode string `json:"sqlcode"`
+}
+
+var upgrader = websocket.Upgrader{}
+
+func newStandin(t *testing.T) *standin {
+	s := newUnstartedStandin(t)
+	s.server.Start()
+	return s
+}
+
+// newTLSStandin creates a standin which only accepts encrypted connections
+func newTLSStandin(t *testing.T) *standin {
+	s := newUnstartedStandin(t)
+	s.server.StartTLS()
+	return s
+}
+
+func newUnstartedStandin(t *testing.T) *standin {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &standin{
+		key:     key,
+		failing: map[string]standinException{},
+		hanging: map[string]bool{},
+	}
+	s.server = httptest.NewUnstartedServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// mustLock tries Lock and fails hard if it does not work
+func mustLock(c *Client) *Locked {
+	locked, err := c.Lock()
+	if err != nil {
+		panic(err)
+	}
+	return locked
+}
+
+func (s *standin) conf() exasol.ConnConf {
+	u, _ := url.Parse(s.server.URL)
+	host, portString, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portString)
+	return exasol.ConnConf{
+		Host:          host,
+		Port:          uint16(port),
+		Username:      "sys",
+		Password:      "exasol",
+		SuppressError: true,
+	}
+}
+
+func (s *standin) fail(sql string, e standinException) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.failing[sql] = e
+}
+
+func (s *standin) succeed(sql string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.failing, sql)
+}
+
+func (s *standin) hang(sql string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.hanging[sql] = true
+}
+
+func (s *standin) dropAttributes() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.silent = true
+}
+
+func (s *standin) abortCount() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.aborts
+}
+
+func (s *standin) record(req map[string]interface{}) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.logins = append(s.logins, req)
+}
+
+func (s *standin) recordedLogins() []map[string]interface{} {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]map[string]interface{}{}, s.logins...)
+}
+
+func (s *standin) connectCount() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.connects
+}
+
+func (s *standin) executed() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return append([]string{}, s.statements...)
+}
+
+func (s *standin) serve(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	s.mux.Lock()
+	s.connects++
+	s.mux.Unlock()
+
+	// hung tells whether a statement waits for abortQuery
+	hung := false
+	for {
+		req := map[string]interface{}{}
+		err := ws.ReadJSON(&req)
+		if err != nil {
+			return
+		}
+		var resp map[string]interface{}
+		switch {
+		case req["command"] == "abortQuery":
+			s.mux.Lock()
+			s.aborts++
+			s.mux.Unlock()
+			if !hung {
+				continue
+			}
+			hung = false
+			resp = map[string]interface{}{
+				"status": "error",
+				"exception": standinException{
+					Text:    "Query aborted",
+					Sqlcode: "R0001",
+				},
+			}
+		case s.hangs(req):
+			hung = true
+			continue
+		default:
+			resp = s.respond(req)
+		}
+		err = ws.WriteJSON(resp)
+		if err != nil {
+			return
+		}
+		if req["command"] == "disconnect" {
+			return
+		}
+	}
+}
+
+func (s *standin) hangs(req map[string]interface{}) bool {
+	if req["command"] == "getAttributes" {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		return s.silent
+	}
+	if req["command"] != "execute" {
+		return false
+	}
+	sql, _ := req["sqlText"].(string)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if !s.hanging[sql] {
+		return false
+	}
+	s.statements = append(s.statements, sql)
+	return true
+}
+
+func (s *standin) respond(req map[string]interface{}) map[string]interface{} {
+	switch req["command"] {
+	case "loginToken":
+		s.record(req)
+		// Token logins do not need a public key
+		return map[string]interface{}{
+			"status": "ok",
+		}
+	case "login":
+		s.record(req)
+		return map[string]interface{}{
+			"status": "ok",
+			"responseData": map[string]interface{}{
+				"publicKeyModulus":  fmt.Sprintf("%x", s.key.N),
+				"publicKeyExponent": fmt.Sprintf("%x", s.key.E),
+			},
+		}
+	case nil:
+		// Authentication request carries no command
+		s.record(req)
+		s.mux.Lock()
+		reject := s.rejectLogin
+		s.mux.Unlock()
+		if reject {
+			return map[string]interface{}{
+				"status": "error",
+				"exception": standinException{
+					Text:    "Connection exception - authentication failed.",
+					Sqlcode: "08004",
+				},
+			}
+		}
+		return map[string]interface{}{
+			"status": "ok",
+			"responseData": map[string]interface{}{
+				"sessionId": 1,
+			},
+		}
+	case "execute":
+		sql, _ := req["sqlText"].(string)
+		s.mux.Lock()
+		s.statements = append(s.statements, sql)
+		e, failing := s.failing[sql]
+		s.mux.Unlock()
+		if failing {
+			return map[string]interface{}{
+				"status":    "error",
+				"exception": e,
+			}
+		}
+		return map[string]interface{}{
+			"status": "ok",
+			"responseData": map[string]interface{}{
+				"numResults": 1,
+				"results": []interface{}{
+					map[string]interface{}{
+						"resultType": "rowCount",
+						"rowCount":   0,
+					},
+				},
+			},
+		}
+	case "getAttributes":
+		return map[string]interface{}{
+			"status":     "ok",
+			"attributes": map[string]interface{}{},
+		}
+	default:
+		return map[string]interface{}{
+			"status": "ok",
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package exaprovider
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var fingerprintExp = regexp.MustCompile("^[0-9A-F]{64}$")
+
+// TLSOptions configure the encryption of connections
+type TLSOptions struct {
+	// SkipVerify disables verification of the server certificate chain
+	SkipVerify bool
+	// Fingerprint pins the SHA-256 fingerprint of the server certificate
+	Fingerprint string
+	// CAFile is a PEM file of additionally trusted certificate authorities
+	CAFile string
+}
+
+// FingerprintError is returned when the server certificate
+// does not match the pinned fingerprint
+type FingerprintError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *FingerprintError) Error() string {
+	return fmt.Sprintf("server certificate fingerprint %s does not match expected fingerprint %s", e.Actual, e.Expected)
+}
+
+// NormalizeFingerprint converts a fingerprint into uppercase hex without separators
+func NormalizeFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if !fingerprintExp.MatchString(normalized) {
+		return "", fmt.Errorf("fingerprint %s is not a SHA-256 hex digest", fingerprint)
+	}
+	return normalized, nil
+}
+
+// NewTLSConfig creates the configuration for encrypted connections
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if opts.Fingerprint != "" && opts.CAFile != "" {
+		// Pinning skips chain verification, so the CA file would be ignored
+		return nil, errors.New("certificate fingerprint and CA certificate file cannot be combined")
+	}
+
+	conf := &tls.Config{
+		InsecureSkipVerify: opts.SkipVerify,
+	}
+
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate file %s failed: %s", opts.CAFile, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA certificate file %s", opts.CAFile)
+		}
+		conf.RootCAs = pool
+	}
+
+	if opts.Fingerprint != "" {
+		expected, err := NormalizeFingerprint(opts.Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		// The pinned certificate replaces chain verification
+		conf.InsecureSkipVerify = true
+		conf.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyFingerprint(rawCerts, expected)
+		}
+	}
+
+	return conf, nil
+}
+
+func verifyFingerprint(rawCerts [][]byte, expected string) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	sum := sha256.Sum256(rawCerts[0])
+	actual := strings.ToUpper(hex.EncodeToString(sum[:]))
+	if actual != expected {
+		return &FingerprintError{
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+	return nil
+}
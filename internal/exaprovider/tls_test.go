@@ -0,0 +1,146 @@
+package exaprovider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeFingerprint(t *testing.T) {
+	t.Parallel()
+
+	hexDigest := strings.Repeat("ab", 32)
+	colons := strings.TrimSuffix(strings.Repeat("ab:", 32), ":")
+
+	for _, fp := range []string{hexDigest, strings.ToUpper(hexDigest), colons} {
+		n, err := NormalizeFingerprint(fp)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", fp, err)
+		}
+		if n != strings.ToUpper(hexDigest) {
+			t.Fatalf("Unexpected normalized fingerprint for %s: %s", fp, n)
+		}
+	}
+
+	for _, fp := range []string{"", "abc", strings.Repeat("zz", 32)} {
+		_, err := NormalizeFingerprint(fp)
+		if err == nil {
+			t.Fatalf("Expected error for %s", fp)
+		}
+	}
+}
+
+func TestNewTLSConfigRejectsFingerprintWithCAFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTLSConfig(TLSOptions{
+		Fingerprint: strings.Repeat("ab", 32),
+		CAFile:      "ca.pem",
+	})
+	if err == nil {
+		t.Fatal("Expected CA file not to be ignored silently")
+	}
+}
+
+func TestLockWithFingerprint(t *testing.T) {
+	t.Parallel()
+
+	s := newTLSStandin(t)
+	sum := sha256.Sum256(s.server.Certificate().Raw)
+
+	conf := s.conf()
+	var err error
+	conf.TLSConfig, err = NewTLSConfig(TLSOptions{
+		Fingerprint: hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locked, err := NewClient(conf).Lock()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	locked.Unlock()
+}
+
+func TestLockWithWrongFingerprint(t *testing.T) {
+	t.Parallel()
+
+	s := newTLSStandin(t)
+
+	conf := s.conf()
+	var err error
+	conf.TLSConfig, err = NewTLSConfig(TLSOptions{
+		Fingerprint: strings.Repeat("00", 32),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewClientWithOptions(conf, Options{
+		ConnectRetryTimeout: time.Minute,
+	}).Lock()
+	var fe *FingerprintError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Expected FingerprintError: %#v", err)
+	}
+	if s.connectCount() != 0 {
+		t.Fatalf("Expected no retry and no websocket upgrade: %d", s.connectCount())
+	}
+}
+
+func TestLockWithCAFile(t *testing.T) {
+	t.Parallel()
+
+	s := newTLSStandin(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.server.Certificate().Raw,
+	}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := s.conf()
+	conf.TLSConfig, err = NewTLSConfig(TLSOptions{
+		CAFile: caFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	locked, err := NewClient(conf).Lock()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	locked.Unlock()
+}
+
+func TestLockWithUntrustedCertificate(t *testing.T) {
+	t.Parallel()
+
+	s := newTLSStandin(t)
+
+	conf := s.conf()
+	var err error
+	conf.TLSConfig, err = NewTLSConfig(TLSOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewClientWithOptions(conf, Options{
+		ConnectRetryTimeout: time.Second,
+	}).Lock()
+	if err == nil {
+		t.Fatal("Expected error for untrusted certificate")
+	}
+}
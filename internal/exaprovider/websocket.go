@@ -0,0 +1,263 @@
+package exaprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// tokenProtocolVersion is the first Websocket API version
+	// supporting loginToken
+	tokenProtocolVersion = 3
+)
+
+var (
+	dummyKeyOnce sync.Once
+	dummyKey     *rsa.PrivateKey
+	dummyKeyErr  error
+)
+
+type loginState int
+
+const (
+	loginNone loginState = iota
+	loginAwaitingResponse
+	loginAwaitingAuth
+	loginDone
+)
+
+// wsHandler implements exasol.WSHandler on top of gorilla/websocket.
+// Other than the default handler of go-exasol-client it does not share
+// a Dialer between connections and is able to rewrite the login
+// for token based authentication.
+type wsHandler struct {
+	ws           *websocket.Conn
+	accessToken  string
+	refreshToken string
+	state        loginState
+	// wmux serializes writes since abort writes concurrently
+	// to a running statement
+	wmux sync.Mutex
+	// aborted is the reason for refusing further commands
+	aborted error
+	// exception is the last one reported by the server
+	exception *wsException
+}
+
+type wsException struct {
+	Text    string `json:"text"`
+	SQLCode string `json:"sqlCode"`
+}
+
+func newWSHandler(opts Options) *wsHandler {
+	return &wsHandler{
+		accessToken:  opts.AccessToken,
+		refreshToken: opts.RefreshToken,
+	}
+}
+
+func (h *wsHandler) usesToken() bool {
+	return h.accessToken != "" || h.refreshToken != ""
+}
+
+func (h *wsHandler) Connect(u url.URL, tlsConfig *tls.Config, timeout time.Duration) error {
+	dialer := websocket.Dialer{
+		HandshakeTimeout:  timeout,
+		TLSClientConfig:   tlsConfig,
+		EnableCompression: false,
+	}
+	ws, _, err := dialer.Dial(u.String(), nil)
+	if err != nil {
+		return err
+	}
+	h.ws = ws
+	return nil
+}
+
+func (h *wsHandler) EnableCompression(e bool) {
+	h.ws.EnableWriteCompression(e)
+}
+
+func (h *wsHandler) WriteJSON(req interface{}) error {
+	h.wmux.Lock()
+	defer h.wmux.Unlock()
+	if h.aborted != nil && !isDisconnect(req) {
+		return h.aborted
+	}
+	if h.usesToken() && h.state != loginDone {
+		var err error
+		req, err = h.rewriteLogin(req)
+		if err != nil {
+			return err
+		}
+	}
+	return h.ws.WriteJSON(req)
+}
+
+func (h *wsHandler) ReadJSON(resp interface{}) error {
+	if h.state != loginAwaitingResponse {
+		return h.readResponse(resp)
+	}
+
+	m := map[string]interface{}{}
+	err := h.ws.ReadJSON(&m)
+	if err != nil {
+		return err
+	}
+	if m["status"] == "ok" {
+		// loginToken does not hand out a public key. go-exasol-client
+		// insists on encrypting a password so we give it a key which
+		// is thrown away with the password.
+		key, err := loginDummyKey()
+		if err != nil {
+			return err
+		}
+		m["responseData"] = map[string]interface{}{
+			"publicKeyModulus":  fmt.Sprintf("%x", key.N),
+			"publicKeyExponent": fmt.Sprintf("%x", key.E),
+		}
+		h.state = loginAwaitingAuth
+	}
+	return convertJSON(m, resp)
+}
+
+// readResponse keeps the exception of failed responses since
+// go-exasol-client only hands out its text
+func (h *wsHandler) readResponse(resp interface{}) error {
+	var raw json.RawMessage
+	err := h.ws.ReadJSON(&raw)
+	if err != nil {
+		return err
+	}
+	status := struct {
+		Status    string       `json:"status"`
+		Exception *wsException `json:"exception"`
+	}{}
+	err = json.Unmarshal(raw, &status)
+	if err == nil && status.Status != "ok" && status.Exception != nil {
+		h.exception = status.Exception
+	}
+	return json.Unmarshal(raw, resp)
+}
+
+// abort cancels the running statement and refuses all further
+// commands except for disconnecting
+func (h *wsHandler) abort(reason error) {
+	h.wmux.Lock()
+	defer h.wmux.Unlock()
+	if h.aborted != nil || h.ws == nil {
+		return
+	}
+	h.aborted = reason
+	// abortQuery has no response so it does not interfere
+	// with reading the result of the running statement
+	_ = h.ws.WriteJSON(map[string]interface{}{
+		"command": "abortQuery",
+	})
+}
+
+// limit bounds reads and writes of fn by timeout and the deadline
+// of ctx. Once ctx is done pending reads and writes fail immediately.
+func (h *wsHandler) limit(ctx context.Context, timeout time.Duration, fn func() error) error {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	h.setDeadline(deadline)
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			h.setDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	err := fn()
+	close(stop)
+	<-stopped
+	h.setDeadline(time.Time{})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (h *wsHandler) setDeadline(t time.Time) {
+	_ = h.ws.SetReadDeadline(t)
+	_ = h.ws.SetWriteDeadline(t)
+}
+
+func (h *wsHandler) Close() {
+	h.wmux.Lock()
+	defer h.wmux.Unlock()
+	h.ws.Close()
+	h.ws = nil
+}
+
+// rewriteLogin replaces the password login of go-exasol-client
+// with loginToken
+func (h *wsHandler) rewriteLogin(req interface{}) (interface{}, error) {
+	m := map[string]interface{}{}
+	err := convertJSON(req, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	switch h.state {
+	case loginNone:
+		if m["command"] != "login" {
+			return req, nil
+		}
+		h.state = loginAwaitingResponse
+		return map[string]interface{}{
+			"command":         "loginToken",
+			"protocolVersion": tokenProtocolVersion,
+		}, nil
+	case loginAwaitingAuth:
+		delete(m, "username")
+		delete(m, "password")
+		if h.accessToken != "" {
+			m["accessToken"] = h.accessToken
+		} else {
+			m["refreshToken"] = h.refreshToken
+		}
+		h.state = loginDone
+		return m, nil
+	}
+	return req, nil
+}
+
+func isDisconnect(req interface{}) bool {
+	m := map[string]interface{}{}
+	err := convertJSON(req, &m)
+	return err == nil && m["command"] == "disconnect"
+}
+
+func loginDummyKey() (*rsa.PrivateKey, error) {
+	dummyKeyOnce.Do(func() {
+		dummyKey, dummyKeyErr = rsa.GenerateKey(rand.Reader, 1024)
+	})
+	return dummyKey, dummyKeyErr
+}
+
+// convertJSON converts between two JSON compatible representations
+func convertJSON(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
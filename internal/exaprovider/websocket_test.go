@@ -0,0 +1,85 @@
+package exaprovider
+
+import (
+	"testing"
+)
+
+func TestPasswordLogin(t *testing.T) {
+	t.Parallel()
+
+	s := newStandin(t)
+	locked := mustLock(NewClient(s.conf()))
+	locked.Unlock()
+
+	logins := s.recordedLogins()
+	if len(logins) != 2 {
+		t.Fatalf("Expected login and authentication: %#v", logins)
+	}
+	if logins[0]["command"] != "login" {
+		t.Fatalf("Expected login command: %#v", logins[0])
+	}
+	if logins[1]["username"] != "sys" {
+		t.Fatalf("Expected username: %#v", logins[1])
+	}
+	if _, ok := logins[1]["password"]; !ok {
+		t.Fatalf("Expected password: %#v", logins[1])
+	}
+}
+
+func TestTokenLogin(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		opts Options
+		key  string
+	}{
+		{
+			name: "access",
+			opts: Options{AccessToken: "myaccess"},
+			key:  "accessToken",
+		},
+		{
+			name: "refresh",
+			opts: Options{RefreshToken: "myrefresh"},
+			key:  "refreshToken",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := newStandin(t)
+			conf := s.conf()
+			conf.Password = ""
+			c := NewClientWithOptions(conf, tc.opts)
+
+			for i := 0; i < 2; i++ {
+				locked := mustLock(c)
+				locked.Unlock()
+			}
+
+			logins := s.recordedLogins()
+			if len(logins) != 2 {
+				t.Fatalf("Expected login and authentication: %#v", logins)
+			}
+			if logins[0]["command"] != "loginToken" {
+				t.Fatalf("Expected loginToken command: %#v", logins[0])
+			}
+			if logins[0]["protocolVersion"] != float64(tokenProtocolVersion) {
+				t.Fatalf("Unexpected protocol version: %#v", logins[0])
+			}
+
+			auth := logins[1]
+			expected := tc.opts.AccessToken + tc.opts.RefreshToken
+			if auth[tc.key] != expected {
+				t.Fatalf("Expected %s %s: %#v", tc.key, expected, auth)
+			}
+			for _, k := range []string{"username", "password"} {
+				if _, ok := auth[k]; ok {
+					t.Fatalf("Did not expect %s: %#v", k, auth)
+				}
+			}
+		})
+	}
+}
@@ -1,23 +1,24 @@
 // Package globallock is handling global lock behavior in Exasol.
-// Mutations on Connections and Users are not allowed to
-// happen in parallel and Exasol will automatically rollback
-// the Transaction.
+// Parallel mutations of database objects can collide and Exasol
+// will automatically rollback the Transaction. All mutations
+// therefore go through Mutate. Mutations of global objects like
+// Users, Roles and Connections go through MutateClass, which
+// queues them within the provider.
 package globallock
 
 import (
-	"regexp"
-)
+	"errors"
 
-var rollbackExp = regexp.MustCompile("GlobalTransactionRollback msg: Transaction collision: automatic transaction rollback.")
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+)
 
 // IsRollbackError checks whether there is an error
 // and whether the error is due to an Exasol
 // rollback
 func IsRollbackError(err error) bool {
-	if err == nil {
+	var se *exaprovider.ServerError
+	if !errors.As(err, &se) {
 		return false
 	}
-
-	// Currently there seems to be no better way than to compare error strings
-	return rollbackExp.MatchString(err.Error())
+	return se.SQLState == exaprovider.SQLStateTransactionCollision
 }
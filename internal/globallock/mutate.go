@@ -0,0 +1,101 @@
+package globallock
+
+import (
+	"context"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// lockFunc hands out a connection and the function to release it
+type lockFunc func(ctx context.Context) (internal.Conn, func(), error)
+
+// Mutate runs fun on a locked connection and commits afterwards.
+// Whenever Exasol rolls back the transaction due to a collision,
+// the whole operation is retried.
+func Mutate(ctx context.Context, c *exaprovider.Client, fun func(c internal.Conn) diag.Diagnostics) diag.Diagnostics {
+	return mutate(ctx, c.MaxRetryAttempts(), clientLock(c, ""), fun)
+}
+
+// MutateClass is Mutate for global objects. Mutations of the same
+// class are queued within the provider so they do not collide.
+func MutateClass(ctx context.Context, c *exaprovider.Client, class exaprovider.ObjectClass, fun func(c internal.Conn) diag.Diagnostics) diag.Diagnostics {
+	return mutate(ctx, c.MaxRetryAttempts(), clientLock(c, class), fun)
+}
+
+// clientLock locks a connection of c. With class set, mutations
+// of the class are serialized before taking a connection.
+func clientLock(c *exaprovider.Client, class exaprovider.ObjectClass) lockFunc {
+	return func(ctx context.Context) (internal.Conn, func(), error) {
+		release := func() {}
+		if class != "" {
+			var err error
+			release, err = c.LockClass(ctx, class)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		locked, err := c.LockContext(ctx)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		return locked.Conn, func() {
+			locked.Unlock()
+			release()
+		}, nil
+	}
+}
+
+func mutate(ctx context.Context, maxAttempts int, lock lockFunc, fun func(c internal.Conn) diag.Diagnostics) diag.Diagnostics {
+	return retry(ctx, maxAttempts, func() (diag.Diagnostics, error) {
+		conn, unlock, err := lock(ctx)
+		if err != nil {
+			return exaprovider.ConnectDiagnostics(err), nil
+		}
+		defer unlock()
+
+		cc := &collisionConn{Conn: conn}
+		diags := fun(cc)
+		if cc.collision != nil {
+			return nil, cc.collision
+		}
+		if diags.HasError() {
+			return diags, nil
+		}
+		err = cc.Commit()
+		if cc.collision != nil {
+			return nil, cc.collision
+		}
+		return append(diags, diag.FromErr(err)...), nil
+	})
+}
+
+// collisionConn remembers whether Exasol rolled back the
+// transaction. Callers are free to turn errors into Diagnostics.
+type collisionConn struct {
+	internal.Conn
+	collision error
+}
+
+func (c *collisionConn) Commit() error {
+	return c.note(c.Conn.Commit())
+}
+
+func (c *collisionConn) Execute(sql string, args ...interface{}) (int64, error) {
+	rows, err := c.Conn.Execute(sql, args...)
+	return rows, c.note(err)
+}
+
+func (c *collisionConn) FetchSlice(sql string, args ...interface{}) ([][]interface{}, error) {
+	res, err := c.Conn.FetchSlice(sql, args...)
+	return res, c.note(err)
+}
+
+func (c *collisionConn) note(err error) error {
+	if IsRollbackError(err) && c.collision == nil {
+		c.collision = err
+	}
+	return err
+}
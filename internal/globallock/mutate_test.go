@@ -0,0 +1,113 @@
+package globallock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func testLock(conn internal.Conn, unlocks *int) lockFunc {
+	return func(ctx context.Context) (internal.Conn, func(), error) {
+		return conn, func() { *unlocks++ }, nil
+	}
+}
+
+func TestMutateRetriesCollisionOnExecute(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Errors: map[string][]error{
+			"CREATE ROLE R": {collision},
+		},
+	}
+	unlocks := 0
+	diags := mutate(context.Background(), 3, testLock(conn, &unlocks), func(c internal.Conn) diag.Diagnostics {
+		_, err := c.Execute("CREATE ROLE R")
+		// Resources may turn errors into Diagnostics
+		return diag.FromErr(err)
+	})
+
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("Expected retry warning: %#v", diags)
+	}
+	d := cmp.Diff(conn.Statements, []string{"CREATE ROLE R", "CREATE ROLE R", "COMMIT"})
+	if d != "" {
+		t.Fatalf("Unexpected statements:\n%s", d)
+	}
+	if unlocks != 2 {
+		t.Fatalf("Expected connection to be released per attempt: %d", unlocks)
+	}
+}
+
+func TestMutateRetriesCollisionOnCommit(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Errors: map[string][]error{
+			"COMMIT": {collision, collision},
+		},
+	}
+	unlocks := 0
+	diags := mutate(context.Background(), 3, testLock(conn, &unlocks), func(c internal.Conn) diag.Diagnostics {
+		_, err := c.Execute("DROP USER U")
+		return diag.FromErr(err)
+	})
+
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+	d := cmp.Diff(conn.Statements, []string{"DROP USER U", "COMMIT", "DROP USER U", "COMMIT", "DROP USER U", "COMMIT"})
+	if d != "" {
+		t.Fatalf("Unexpected statements:\n%s", d)
+	}
+}
+
+func TestMutateDoesNotCommitOnError(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Errors: map[string][]error{
+			"DROP TABLE T": {errors.New("table T not found")},
+		},
+	}
+	unlocks := 0
+	diags := mutate(context.Background(), 3, testLock(conn, &unlocks), func(c internal.Conn) diag.Diagnostics {
+		_, err := c.Execute("DROP TABLE T")
+		return diag.FromErr(err)
+	})
+
+	if !diags.HasError() {
+		t.Fatal("Expected error")
+	}
+	d := cmp.Diff(conn.Statements, []string{"DROP TABLE T"})
+	if d != "" {
+		t.Fatalf("Unexpected statements:\n%s", d)
+	}
+}
+
+func TestMutateLockError(t *testing.T) {
+	t.Parallel()
+
+	lock := func(ctx context.Context) (internal.Conn, func(), error) {
+		return nil, nil, errors.New("unreachable")
+	}
+	called := false
+	diags := mutate(context.Background(), 3, lock, func(c internal.Conn) diag.Diagnostics {
+		called = true
+		return nil
+	})
+
+	if !diags.HasError() {
+		t.Fatal("Expected error")
+	}
+	if called {
+		t.Fatal("Expected no mutation without connection")
+	}
+}
@@ -1,12 +1,66 @@
 package globallock
 
-func RunAndRetryRollbacks(fun func() error) error {
-	for {
-		err := fun()
-		if IsRollbackError(err) {
-			// Ignore error
-			continue
-		}
-		return err
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var (
+	// initialInterval is the wait before the first retry
+	initialInterval = 100 * time.Millisecond
+	// maxInterval bounds the exponential growth of waits
+	maxInterval = 10 * time.Second
+)
+
+// retry runs attempt until it reports no collision
+func retry(ctx context.Context, maxAttempts int, attempt func() (diag.Diagnostics, error)) diag.Diagnostics {
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
+	var diags diag.Diagnostics
+	interval := initialInterval
+	for i := 1; ; i++ {
+		attemptDiags, collision := attempt()
+		if collision == nil {
+			return append(diags, attemptDiags...)
+		}
+		if i >= maxAttempts {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Transaction collision persisted after %d attempts", i),
+				Detail:   collision.Error(),
+			})
+		}
+
+		wait := jitter(interval)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Transaction collision, retrying",
+			Detail:   fmt.Sprintf("Attempt %d of %d was rolled back by Exasol. Retrying in %s: %s", i, maxAttempts, wait, collision),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return append(diags, diag.FromErr(ctx.Err())...)
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// jitter spreads waits of parallel retries between half
+// and the full interval
+func jitter(interval time.Duration) time.Duration {
+	half := interval / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
 }
@@ -0,0 +1,118 @@
+package globallock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var collision = &exaprovider.ServerError{
+	SQLState: exaprovider.SQLStateTransactionCollision,
+	Text:     "GlobalTransactionRollback msg: Transaction collision: automatic transaction rollback.",
+	Err:      errors.New("Unable to commit: Server Error: GlobalTransactionRollback msg: Transaction collision: automatic transaction rollback."),
+}
+
+func TestIsRollbackError(t *testing.T) {
+	t.Parallel()
+
+	if IsRollbackError(nil) {
+		t.Fatal("Expected nil not to be a rollback")
+	}
+	if IsRollbackError(errors.New(collision.Err.Error())) {
+		t.Fatal("Expected message alone not to be classified as rollback")
+	}
+	other := &exaprovider.ServerError{
+		SQLState: "42000",
+		Err:      errors.New("syntax error"),
+	}
+	if IsRollbackError(other) {
+		t.Fatal("Expected syntax error not to be a rollback")
+	}
+	if !IsRollbackError(collision) {
+		t.Fatal("Expected collision to be a rollback")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	diags := retry(context.Background(), 5, func() (diag.Diagnostics, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, collision
+		}
+		return nil, nil
+	})
+
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts: %d", attempts)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("Expected warning per retry: %#v", diags)
+	}
+	for _, d := range diags {
+		if d.Severity != diag.Warning {
+			t.Fatalf("Expected warning: %#v", d)
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	diags := retry(context.Background(), 2, func() (diag.Diagnostics, error) {
+		attempts++
+		return nil, collision
+	})
+
+	if !diags.HasError() {
+		t.Fatal("Expected error")
+	}
+	if attempts != 2 {
+		t.Fatalf("Expected 2 attempts: %d", attempts)
+	}
+}
+
+func TestRetryOtherError(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	diags := retry(context.Background(), 5, func() (diag.Diagnostics, error) {
+		attempts++
+		return diag.FromErr(errors.New("broken")), nil
+	})
+
+	if !diags.HasError() || len(diags) != 1 {
+		t.Fatalf("Expected only error: %#v", diags)
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected no retry: %d", attempts)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	diags := retry(ctx, 100, func() (diag.Diagnostics, error) {
+		attempts++
+		cancel()
+		return nil, collision
+	})
+
+	if !diags.HasError() {
+		t.Fatal("Expected error")
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected to stop after cancel: %d attempts", attempts)
+	}
+}
@@ -2,8 +2,15 @@ package resourceprovider
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/credentials"
 	"github.com/abergmeier/terraform-provider-exasol/internal/datasources"
 	dconn "github.com/abergmeier/terraform-provider-exasol/internal/datasources/connection"
 	drole "github.com/abergmeier/terraform-provider-exasol/internal/datasources/role"
@@ -12,13 +19,28 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/internal/resources"
 	rconn "github.com/abergmeier/terraform-provider-exasol/internal/resources/connection"
+	rconsumergroup "github.com/abergmeier/terraform-provider-exasol/internal/resources/consumergroup"
+	rfunction "github.com/abergmeier/terraform-provider-exasol/internal/resources/function"
+	rgranteeprivileges "github.com/abergmeier/terraform-provider-exasol/internal/resources/granteeprivileges"
+	robjprivilege "github.com/abergmeier/terraform-provider-exasol/internal/resources/objectprivilege"
 	rrole "github.com/abergmeier/terraform-provider-exasol/internal/resources/role"
+	rrolegrant "github.com/abergmeier/terraform-provider-exasol/internal/resources/rolegrant"
+	rscript "github.com/abergmeier/terraform-provider-exasol/internal/resources/script"
+	rscriptlanguage "github.com/abergmeier/terraform-provider-exasol/internal/resources/scriptlanguage"
+	rsysparameter "github.com/abergmeier/terraform-provider-exasol/internal/resources/systemparameter"
+	rsysprivilege "github.com/abergmeier/terraform-provider-exasol/internal/resources/systemprivilege"
 	rtable "github.com/abergmeier/terraform-provider-exasol/internal/resources/table"
 	ruser "github.com/abergmeier/terraform-provider-exasol/internal/resources/user"
 	rview "github.com/abergmeier/terraform-provider-exasol/internal/resources/view"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultPort = 8563
 )
 
 func Provider() *schema.Provider {
@@ -31,12 +53,22 @@ func Provider() *schema.Provider {
 			"exasol_view":            dview.Resource(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"exasol_connection":      rconn.Resource(),
-			"exasol_physical_schema": resources.PhysicalSchema(),
-			"exasol_role":            rrole.Resource(),
-			"exasol_table":           rtable.Resource(),
-			"exasol_user":            ruser.Resource(),
-			"exasol_view":            rview.Resource(),
+			"exasol_connection":         rconn.Resource(),
+			"exasol_consumer_group":     rconsumergroup.Resource(),
+			"exasol_function":           rfunction.Resource(),
+			"exasol_grantee_privileges": rgranteeprivileges.Resource(),
+			"exasol_object_privilege":   robjprivilege.Resource(),
+			"exasol_physical_schema":    resources.PhysicalSchema(),
+			"exasol_role":               rrole.Resource(),
+			"exasol_role_grant":         rrolegrant.Resource(),
+			"exasol_script":             rscript.Resource(),
+			"exasol_script_language":    rscriptlanguage.Resource(),
+			"exasol_system_parameter":   rsysparameter.Resource(),
+			"exasol_system_privilege":   rsysprivilege.Resource(),
+			"exasol_table":              rtable.Resource(),
+			"exasol_user":               ruser.Resource(),
+			"exasol_view":               rview.Resource(),
+			"exasol_virtual_schema":     resources.VirtualSchema(),
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -45,20 +77,101 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("EXAUID", nil),
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("EXAPWD", nil),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Password to login with. Defaults to EXAPWD",
+				ConflictsWith: []string{"access_token", "refresh_token"},
+			},
+			"access_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "OpenID access token to login with instead of password. Defaults to EXAACCESSTOKEN",
+				ConflictsWith: []string{"password", "refresh_token"},
+			},
+			"refresh_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "OpenID refresh token to login with instead of password. Defaults to EXAREFRESHTOKEN",
+				ConflictsWith: []string{"password", "access_token"},
 			},
 			"ip": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("EXAHOST", nil),
 			},
 			"port": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  8563,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  fmt.Sprintf("Port of Exasol. Defaults to %d", defaultPort),
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("EXACREDENTIALS", nil),
+				Description: "JSON or INI file with host, port, username, password and fingerprint. Values set on the provider take precedence. A fingerprint in the file enables encryption unless encryption = false is set.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     credentials.DefaultProfile,
+				Description: "Profile to use from credentials_file",
+			},
+			"max_connections": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      exaprovider.DefaultMaxConnections,
+				Description:  "Maximum number of connections kept open to Exasol",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"encryption": {
+				// No Default so that an explicit false is distinguishable
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to encrypt connections using TLS. Defaults to false",
+			},
+			"tls_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip verification of the server certificate chain",
+			},
+			"certificate_fingerprint": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "SHA-256 fingerprint the server certificate has to match",
+				ValidateFunc:  validateFingerprint,
+				ConflictsWith: []string{"ca_certificate_file"},
+			},
+			"ca_certificate_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM file of certificate authorities to trust",
+				ConflictsWith: []string{"certificate_fingerprint"},
+			},
+			"session_parameters": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  "Session parameters applied with ALTER SESSION on every connection. Values are checked by opening a connection when the provider is configured",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateSessionParameters,
+			},
+			"max_retry_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      exaprovider.DefaultMaxRetryAttempts,
+				Description:  "Maximum number of attempts for operations which Exasol rolled back due to transaction collisions",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"connect_retry_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      exaprovider.DefaultConnectRetryTimeout.String(),
+				Description:  "Duration in which transient connection failures are retried",
+				ValidateFunc: validateDuration,
 			},
 		},
 	}
@@ -71,19 +184,254 @@ func Provider() *schema.Provider {
 			terraformVersion = "0.11+compatible"
 		}
 		m, err := providerConfigure(d)
-		return m, diag.FromErr(err)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		client := m.(*exaprovider.Client)
+		if _, ok := d.GetOk("session_parameters"); ok {
+			// Session parameters are only checked by Exasol. Fail
+			// now instead of halfway through an apply.
+			err = client.Verify(ctx)
+			if err != nil {
+				client.Close()
+				return nil, exaprovider.ConnectDiagnostics(err)
+			}
+		}
+		if stop, ok := schema.StopContext(ctx); ok {
+			// Do not leave pooled connections behind once Terraform
+			// stops the provider
+			go func() {
+				<-stop.Done()
+				client.Close()
+			}()
+		}
+		return m, nil
 	}
 	return provider
 }
 
 func providerConfigure(d internal.Data) (interface{}, error) {
+	conf, opts, err := connConfigure(d)
+	if err != nil {
+		return nil, err
+	}
+	return exaprovider.NewClientWithOptions(conf, opts), nil
+}
+
+// connConfigure merges the provider arguments with credentials_file
+// and the environment. Arguments set on the provider take precedence.
+func connConfigure(d internal.Data) (exasol.ConnConf, exaprovider.Options, error) {
+	profile, err := readCredentials(d)
+	if err != nil {
+		return exasol.ConnConf{}, exaprovider.Options{}, err
+	}
 
 	conf := exasol.ConnConf{
-		Host:     d.Get("ip").(string),
-		Port:     uint16(d.Get("port").(int)),
-		Username: d.Get("username").(string),
-		Password: d.Get("password").(string),
+		Host:     stringOrProfile(d, "ip", profile.Host),
+		Username: stringOrProfile(d, "username", profile.Username),
+	}
+	if conf.Host == "" {
+		return exasol.ConnConf{}, exaprovider.Options{}, errors.New("ip has to be set in the provider, via EXAHOST or in credentials_file")
+	}
+
+	port, ok := d.GetOk("port")
+	if ok {
+		conf.Port = uint16(port.(int))
+	} else if profile.Port != 0 {
+		conf.Port = uint16(profile.Port)
+	} else {
+		conf.Port = defaultPort
+	}
+
+	tlsConfig, err := tlsConfigure(d, profile)
+	if err != nil {
+		return exasol.ConnConf{}, exaprovider.Options{}, err
+	}
+	conf.TLSConfig = tlsConfig
+
+	connectRetryTimeout, err := time.ParseDuration(d.Get("connect_retry_timeout").(string))
+	if err != nil {
+		return exasol.ConnConf{}, exaprovider.Options{}, err
+	}
+
+	opts := exaprovider.Options{
+		MaxConnections:      d.Get("max_connections").(int),
+		ConnectRetryTimeout: connectRetryTimeout,
+		MaxRetryAttempts:    d.Get("max_retry_attempts").(int),
+	}
+	sessionParams := d.Get("session_parameters").(map[string]interface{})
+	if len(sessionParams) != 0 {
+		opts.SessionParameters = make(map[string]string, len(sessionParams))
+		for name, value := range sessionParams {
+			opts.SessionParameters[name] = value.(string)
+		}
+	}
+	l, err := loginConfigure(d, profile)
+	if err != nil {
+		return exasol.ConnConf{}, exaprovider.Options{}, err
+	}
+	conf.Password = l.password
+	opts.AccessToken = l.accessToken
+	opts.RefreshToken = l.refreshToken
+
+	return conf, opts, nil
+}
+
+func validateDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	_, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid duration: %s", k, err)}
+	}
+	return nil, nil
+}
+
+func validateSessionParameters(i interface{}, k string) ([]string, []error) {
+	m, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be map", k)}
+	}
+	var errs []error
+	for name, value := range m {
+		s, ok := value.(string)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: value of %s has to be a string", k, name))
+			continue
+		}
+		err := exaprovider.ValidateSessionParameter(name, s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", k, err))
+		}
+	}
+	return nil, errs
+}
+
+func validateFingerprint(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	_, err := exaprovider.NormalizeFingerprint(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// login holds the secret to login with. At most one is set.
+type login struct {
+	password     string
+	accessToken  string
+	refreshToken string
+}
+
+// loginArguments lists the login arguments and the environment
+// variables they default to
+var loginArguments = []struct {
+	name string
+	env  string
+}{
+	{"password", "EXAPWD"},
+	{"access_token", "EXAACCESSTOKEN"},
+	{"refresh_token", "EXAREFRESHTOKEN"},
+}
+
+// getenv looks up environment variables. Replaced in tests.
+var getenv = os.Getenv
+
+// loginConfigure picks the secret to login with. Arguments set on the
+// provider take precedence over environment variables, which take
+// precedence over the password of credentials_file.
+func loginConfigure(d internal.Data, profile credentials.Profile) (login, error) {
+	values := make([]string, len(loginArguments))
+	var sources []string
+	for i, a := range loginArguments {
+		v, _ := argument.GetOkAsString(d, a.name)
+		if v != "" {
+			values[i] = v
+			sources = append(sources, a.name)
+		}
+	}
+	if len(sources) == 0 {
+		for i, a := range loginArguments {
+			v := getenv(a.env)
+			if v != "" {
+				values[i] = v
+				sources = append(sources, a.env)
+			}
+		}
+	}
+	if len(sources) > 1 {
+		return login{}, fmt.Errorf("only one of password, access_token and refresh_token may be used but got %s", strings.Join(sources, ", "))
+	}
+	if len(sources) == 0 {
+		values[0] = profile.Password
+	}
+	return login{
+		password:     values[0],
+		accessToken:  values[1],
+		refreshToken: values[2],
+	}, nil
+}
+
+// readCredentials reads the selected profile of credentials_file.
+// Returns an empty Profile if no file is configured.
+func readCredentials(d internal.Data) (credentials.Profile, error) {
+	file, _ := argument.GetOkAsString(d, "credentials_file")
+	if file == "" {
+		return credentials.Profile{}, nil
+	}
+	profile, _ := argument.GetOkAsString(d, "profile")
+	return credentials.ReadProfile(file, profile)
+}
+
+// stringOrProfile prefers the value set on the provider over the profile value
+func stringOrProfile(d internal.Data, name, profileValue string) string {
+	v, _ := argument.GetOkAsString(d, name)
+	if v != "" {
+		return v
+	}
+	return profileValue
+}
+
+// explicitBool returns the value of a bool argument and whether
+// it was set at all
+func explicitBool(d internal.Data, name string) (value bool, set bool) {
+	var v interface{}
+	if e, ok := d.(interface {
+		GetOkExists(string) (interface{}, bool)
+	}); ok {
+		v, set = e.GetOkExists(name)
+	} else {
+		v, set = d.GetOk(name)
+	}
+	value, _ = v.(bool)
+	return value, set
+}
+
+// tlsConfigure returns the TLS configuration or nil for unencrypted connections
+func tlsConfigure(d internal.Data, profile credentials.Profile) (*tls.Config, error) {
+	opts := exaprovider.TLSOptions{
+		SkipVerify: d.Get("tls_skip_verify").(bool),
+	}
+	opts.Fingerprint, _ = argument.GetOkAsString(d, "certificate_fingerprint")
+	opts.CAFile, _ = argument.GetOkAsString(d, "ca_certificate_file")
+
+	encryption, encryptionSet := explicitBool(d, "encryption")
+	if opts.Fingerprint == "" && opts.CAFile == "" && profile.Fingerprint != "" && (encryption || !encryptionSet) {
+		opts.Fingerprint = profile.Fingerprint
+		encryption = true
+	}
+
+	if !encryption {
+		if opts.SkipVerify || opts.Fingerprint != "" || opts.CAFile != "" {
+			return nil, errors.New("tls_skip_verify, certificate_fingerprint and ca_certificate_file require encryption = true")
+		}
+		return nil, nil
 	}
 
-	return exaprovider.NewClient(conf), nil
+	return exaprovider.NewTLSConfig(opts)
 }
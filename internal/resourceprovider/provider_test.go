@@ -0,0 +1,229 @@
+package resourceprovider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/credentials"
+)
+
+func fakeEnv(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+func TestLoginConfigure(t *testing.T) {
+	defer func(old func(string) string) {
+		getenv = old
+	}(getenv)
+
+	tests := map[string]struct {
+		values   map[string]interface{}
+		env      map[string]string
+		profile  credentials.Profile
+		expected login
+		err      string
+	}{
+		"token beats env password": {
+			values: map[string]interface{}{
+				"access_token": "token",
+			},
+			env: map[string]string{
+				"EXAPWD": "secret",
+			},
+			expected: login{
+				accessToken: "token",
+			},
+		},
+		"env beats profile": {
+			env: map[string]string{
+				"EXAREFRESHTOKEN": "refresh",
+			},
+			profile: credentials.Profile{
+				Password: "fromfile",
+			},
+			expected: login{
+				refreshToken: "refresh",
+			},
+		},
+		"profile password": {
+			profile: credentials.Profile{
+				Password: "fromfile",
+			},
+			expected: login{
+				password: "fromfile",
+			},
+		},
+		"conflicting env": {
+			env: map[string]string{
+				"EXAPWD":         "secret",
+				"EXAACCESSTOKEN": "token",
+			},
+			err: "EXAPWD, EXAACCESSTOKEN",
+		},
+	}
+
+	for name, tt := range tests {
+		getenv = fakeEnv(tt.env)
+		d := &internal.TestData{
+			Values: tt.values,
+		}
+		l, err := loginConfigure(d, tt.profile)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("%s: expected error naming %s: %v", name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if l != tt.expected {
+			t.Fatalf("%s: unexpected login: %#v", name, l)
+		}
+	}
+}
+
+// configData returns provider arguments with their defaults
+func configData(values map[string]interface{}) *internal.TestData {
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"connect_retry_timeout": "1m",
+			"max_connections":       10,
+			"max_retry_attempts":    10,
+			"session_parameters":    map[string]interface{}{},
+			"tls_skip_verify":       false,
+		},
+	}
+	for k, v := range values {
+		d.Values[k] = v
+	}
+	return d
+}
+
+func writeCredentials(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "credentials.json")
+	err := os.WriteFile(file, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestConnConfigureFromProfile(t *testing.T) {
+	defer func(old func(string) string) {
+		getenv = old
+	}(getenv)
+	getenv = fakeEnv(nil)
+
+	file := writeCredentials(t, `{"host": "filehost", "port": 9000, "username": "fileuser", "password": "filepwd"}`)
+
+	conf, opts, err := connConfigure(configData(map[string]interface{}{
+		"credentials_file": file,
+		"username":         "hcluser",
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.Host != "filehost" {
+		t.Fatalf("Expected host from profile: %s", conf.Host)
+	}
+	if conf.Username != "hcluser" {
+		t.Fatalf("Expected username from provider: %s", conf.Username)
+	}
+	if conf.Port != 9000 {
+		t.Fatalf("Expected port from profile: %d", conf.Port)
+	}
+	if conf.Password != "filepwd" {
+		t.Fatalf("Expected password from profile: %s", conf.Password)
+	}
+	if opts.AccessToken != "" || opts.RefreshToken != "" {
+		t.Fatalf("Unexpected tokens: %#v", opts)
+	}
+}
+
+func TestConnConfigurePortFallback(t *testing.T) {
+	defer func(old func(string) string) {
+		getenv = old
+	}(getenv)
+	getenv = fakeEnv(nil)
+
+	conf, _, err := connConfigure(configData(map[string]interface{}{
+		"ip": "hclhost",
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.Port != defaultPort {
+		t.Fatalf("Expected default port: %d", conf.Port)
+	}
+
+	file := writeCredentials(t, `{"host": "filehost", "port": 9000}`)
+	conf, _, err = connConfigure(configData(map[string]interface{}{
+		"credentials_file": file,
+		"ip":               "hclhost",
+		"port":             8888,
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.Host != "hclhost" || conf.Port != 8888 {
+		t.Fatalf("Expected host and port from provider: %s:%d", conf.Host, conf.Port)
+	}
+}
+
+func TestConnConfigureTokenSkipsProfilePassword(t *testing.T) {
+	defer func(old func(string) string) {
+		getenv = old
+	}(getenv)
+	getenv = fakeEnv(nil)
+
+	file := writeCredentials(t, `{"host": "filehost", "password": "filepwd"}`)
+	conf, opts, err := connConfigure(configData(map[string]interface{}{
+		"credentials_file": file,
+		"refresh_token":    "refresh",
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.Password != "" {
+		t.Fatalf("Expected password of profile to be skipped: %s", conf.Password)
+	}
+	if opts.RefreshToken != "refresh" {
+		t.Fatalf("Expected refresh token: %#v", opts)
+	}
+}
+
+func TestConnConfigureExplicitlyUnencrypted(t *testing.T) {
+	defer func(old func(string) string) {
+		getenv = old
+	}(getenv)
+	getenv = fakeEnv(nil)
+
+	file := writeCredentials(t, `{"host": "filehost", "fingerprint": "`+strings.Repeat("ab", 32)+`"}`)
+
+	conf, _, err := connConfigure(configData(map[string]interface{}{
+		"credentials_file": file,
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.TLSConfig == nil {
+		t.Fatal("Expected fingerprint of profile to enable encryption")
+	}
+
+	conf, _, err = connConfigure(configData(map[string]interface{}{
+		"credentials_file": file,
+		"encryption":       false,
+	}))
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if conf.TLSConfig != nil {
+		t.Fatal("Expected encryption = false to override the profile")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
@@ -12,7 +11,8 @@ import (
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,10 +22,12 @@ func Resource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of connection",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of connection",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
 			"to": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -52,15 +54,19 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importConnection,
 		},
+		Timeouts: resource.Timeouts(),
 	}
 
 }
 
 func readConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
-	err := readConnectionData(d, locked.Conn)
+	err = readConnectionData(d, locked.Conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -79,24 +85,12 @@ func readConnectionData(d internal.Data, c internal.Conn) error {
 
 func createConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := createConnectionData(d, locked.Conn)
-		if err != nil {
-			return err
-		}
-
-		return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createConnectionData(d, conn))
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
 }
 
-func createConnectionData(d internal.Data, c *exasol.Conn) error {
+func createConnectionData(d internal.Data, c internal.Conn) error {
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
@@ -106,51 +100,33 @@ func createConnectionData(d internal.Data, c *exasol.Conn) error {
 	user := resourceUser(d)
 	identifiedBy := resourceIdentifiedBy(d)
 
-	if user == "" {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s'", name, to)
-		_, err = c.Execute(stmt)
-	} else if identifiedBy == "" {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s' USER '%s'", name, to, user)
-		_, err = c.Execute(stmt)
-	} else {
-		stmt := fmt.Sprintf("CREATE CONNECTION %s TO '%s' USER '%s' IDENTIFIED BY '%s'", name, to, user, identifiedBy)
-		_, err = c.Execute(stmt)
-	}
+	quoted := argument.QuotedIdentifier(d)
+	stmt := fmt.Sprintf("CREATE CONNECTION %s %s", quote.Name(name, quoted), connectionTarget(to, user, identifiedBy))
+	_, err = c.Execute(stmt)
 
 	if err != nil {
 		return err
 	}
 
-	d.SetId(strings.ToUpper(name))
+	d.SetId(resource.NewGlobalID(name, quoted))
 	return nil
 }
 
 func deleteConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := deleteConnectionData(d, locked.Conn)
-		if err != nil {
-			return err
-		}
-		return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteConnectionData(d, conn))
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
 }
 
-func deleteConnectionData(d internal.Data, c *exasol.Conn) error {
+func deleteConnectionData(d internal.Data, c internal.Conn) error {
 
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	stmt := fmt.Sprintf("DROP CONNECTION %s", name)
+	stmt := fmt.Sprintf("DROP CONNECTION %s", quote.Name(name, argument.QuotedIdentifier(d)))
 	_, err = c.Execute(stmt)
 	if err != nil {
 		return err
@@ -161,9 +137,12 @@ func deleteConnectionData(d internal.Data, c *exasol.Conn) error {
 
 func importConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importConnectionData(d, locked.Conn)
+	err = importConnectionData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -171,41 +150,38 @@ func importConnection(ctx context.Context, d *schema.ResourceData, meta interfac
 }
 
 func importConnectionData(d internal.Data, c internal.Conn) error {
-	name := d.Id()
-	if name == "" {
+	if d.Id() == "" {
 		return errors.New("import expects id to be set")
 	}
-	err := d.Set("name", name)
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("name", quote.Canonical(name, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
 	if err != nil {
 		return err
 	}
+	d.SetId(resource.NewGlobalID(name, quoted))
 
 	return readConnectionData(d, c)
 }
 
 func updateConnection(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := updateConnectionData(d, locked.Conn)
-		if err != nil {
-			return err
-		}
-		return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateConnectionData(d, conn))
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
 }
 
-func updateConnectionData(d internal.Data, c *exasol.Conn) error {
+func updateConnectionData(d internal.Data, c internal.Conn) error {
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
 
-		err := db.RenameGlobal(c, "CONNECTION", old.(string), new.(string))
+		err := db.RenameGlobal(c, "CONNECTION", old.(string), new.(string), argument.QuotedIdentifier(d))
 		if err != nil {
 			return err
 		}
@@ -222,26 +198,28 @@ func updateConnectionData(d internal.Data, c *exasol.Conn) error {
 	user := resourceUser(d)
 	identifiedBy := resourceIdentifiedBy(d)
 
+	stmt := fmt.Sprintf("ALTER CONNECTION %s %s", quote.Name(name, argument.QuotedIdentifier(d)), connectionTarget(to, user, identifiedBy))
+	_, err = c.Execute(stmt)
+	return err
+}
+
+// connectionTarget renders the TO ... USER ... IDENTIFIED BY ... part
+// of CREATE and ALTER CONNECTION
+func connectionTarget(to, user, identifiedBy string) string {
+	target := fmt.Sprintf("TO %s", quote.Literal(to))
 	if user == "" {
-		stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s'", name, to)
-		_, err := c.Execute(stmt)
-		return err
+		return target
 	}
-
+	target += fmt.Sprintf(" USER %s", quote.Literal(user))
 	if identifiedBy == "" {
-		stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s' USER '%s'", name, to, user)
-		_, err := c.Execute(stmt)
-		return err
+		return target
 	}
-
-	stmt := fmt.Sprintf("ALTER CONNECTION %s TO '%s' USER '%s' IDENTIFIED BY '%s'", name, to, user, identifiedBy)
-	_, err = c.Execute(stmt)
-	return err
+	return target + fmt.Sprintf(" IDENTIFIED BY %s", quote.Literal(identifiedBy))
 }
 
-func Exists(c internal.Conn, name string) (bool, error) {
-	rows, err := c.FetchSlice("SELECT CONNECTION_NAME FROM EXA_DBA_CONNECTIONS WHERE UPPER(CONNECTION_NAME) = UPPER(?)", []interface{}{
-		name,
+func Exists(c internal.Conn, name string, quoted bool) (bool, error) {
+	rows, err := c.FetchSlice("SELECT CONNECTION_NAME FROM EXA_DBA_CONNECTIONS WHERE CONNECTION_NAME = ?", []interface{}{
+		quote.Canonical(name, quoted),
 	}, "SYS")
 	if err != nil {
 		return false, err
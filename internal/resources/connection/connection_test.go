@@ -1,37 +1,37 @@
 package connection
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 func TestCreateConnection(t *testing.T) {
 	t.Parallel()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := exaClient.Lock()
-		defer locked.Unlock()
-
+	diags := globallock.MutateClass(context.Background(), exaClient, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
 		create := &internal.TestData{
 			Values: map[string]interface{}{
 				"name": name,
 				"to":   "me",
 			},
 		}
-		err := deleteConnectionData(create, locked.Conn)
+		err := deleteConnectionData(create, conn)
 		if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = createConnectionData(create, locked.Conn)
+		err = createConnectionData(create, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
@@ -41,8 +41,8 @@ func TestCreateConnection(t *testing.T) {
 		}
 		return nil
 	})
-	if err != nil {
-		t.Fatal(err)
+	if diags.HasError() {
+		t.Fatal(diags)
 	}
 }
 
@@ -50,20 +50,17 @@ func TestDeleteConnection(t *testing.T) {
 	t.Parallel()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := exaClient.Lock()
-		defer locked.Unlock()
-
+	diags := globallock.MutateClass(context.Background(), exaClient, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
 		d := &internal.TestData{
 			Values: map[string]interface{}{
 				"name": name,
 			},
 		}
-		err := deleteConnectionData(d, locked.Conn)
+		err := deleteConnectionData(d, conn)
 		if err == nil {
 			t.Fatal("Expected error")
 		} else if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
 		create := &internal.TestData{
@@ -72,25 +69,25 @@ func TestDeleteConnection(t *testing.T) {
 				"to":   "me",
 			},
 		}
-		err = createConnectionData(create, locked.Conn)
+		err = createConnectionData(create, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
 
-		err = deleteConnectionData(d, locked.Conn)
+		err = deleteConnectionData(d, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
 		return nil
 	})
-	if err != nil {
-		t.Fatal(err)
+	if diags.HasError() {
+		t.Fatal(diags)
 	}
 }
 
@@ -98,25 +95,22 @@ func TestReadConnection(t *testing.T) {
 	t.Parallel()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := exaClient.Lock()
-		defer locked.Unlock()
-
+	diags := globallock.MutateClass(context.Background(), exaClient, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
 		read := &internal.TestData{
 			Values: map[string]interface{}{
 				"name": name,
 			},
 		}
 
-		err := deleteConnectionData(read, locked.Conn)
+		err := deleteConnectionData(read, conn)
 		if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
-		err = readConnectionData(read, locked.Conn)
+		err = readConnectionData(read, conn)
 		if err == nil {
 			t.Fatal("Expected error by readConnectionData")
 		} else if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
 		create := &internal.TestData{
@@ -126,18 +120,18 @@ func TestReadConnection(t *testing.T) {
 			},
 		}
 
-		err = createConnectionData(create, locked.Conn)
+		err = createConnectionData(create, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
 
-		err = readConnectionData(read, locked.Conn)
+		err = readConnectionData(read, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
@@ -149,8 +143,8 @@ func TestReadConnection(t *testing.T) {
 		}
 		return nil
 	})
-	if err != nil {
-		t.Fatal(err)
+	if diags.HasError() {
+		t.Fatal(diags)
 	}
 }
 
@@ -158,42 +152,39 @@ func TestImportConnection(t *testing.T) {
 	t.Parallel()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := exaClient.Lock()
-		defer locked.Unlock()
-
+	diags := globallock.MutateClass(context.Background(), exaClient, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
 		deleteData := &internal.TestData{
 			Values: map[string]interface{}{
 				"name": name,
 			},
 		}
-		err := deleteConnectionData(deleteData, locked.Conn)
+		err := deleteConnectionData(deleteData, conn)
 		if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
 		imp := &internal.TestData{}
 		imp.SetId(name)
-		err = importConnectionData(imp, locked.Conn)
+		err = importConnectionData(imp, conn)
 		if err == nil {
 			t.Fatal("Expected error from importConnectionData")
 		} else if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
 		stmt := fmt.Sprintf("CREATE OR REPLACE CONNECTION %s TO 'http://foo' USER 'foo' IDENTIFIED BY 'bar'", name)
-		_, err = locked.Conn.Execute(stmt)
+		_, err = conn.Execute(stmt)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal(err)
 		}
 
-		err = importConnectionData(imp, locked.Conn)
+		err = importConnectionData(imp, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
@@ -217,8 +208,8 @@ func TestImportConnection(t *testing.T) {
 
 		return nil
 	})
-	if err != nil {
-		t.Fatal(err)
+	if diags.HasError() {
+		t.Fatal(diags)
 	}
 }
 
@@ -226,10 +217,7 @@ func TestUpdateConnection(t *testing.T) {
 	t.Parallel()
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := exaClient.Lock()
-		defer locked.Unlock()
-
+	diags := globallock.MutateClass(context.Background(), exaClient, exaprovider.ObjectClassConnection, func(conn internal.Conn) diag.Diagnostics {
 		create := &internal.TestData{
 			Values: map[string]interface{}{
 				"name": name,
@@ -237,22 +225,22 @@ func TestUpdateConnection(t *testing.T) {
 			},
 		}
 
-		err := deleteConnectionData(create, locked.Conn)
+		err := deleteConnectionData(create, conn)
 		if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = updateConnectionData(create, locked.Conn)
+		err = updateConnectionData(create, conn)
 		if err == nil {
 			t.Fatal("Expected error from updateConnectionData")
 		} else if globallock.IsRollbackError(err) {
-			return err
+			return diag.FromErr(err)
 		}
 
-		err = createConnectionData(create, locked.Conn)
+		err = createConnectionData(create, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
@@ -265,10 +253,10 @@ func TestUpdateConnection(t *testing.T) {
 			},
 		}
 
-		err = updateConnectionData(update, locked.Conn)
+		err = updateConnectionData(update, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpexted error:", err)
 		}
@@ -279,10 +267,10 @@ func TestUpdateConnection(t *testing.T) {
 			},
 		}
 
-		err = readConnectionData(read, locked.Conn)
+		err = readConnectionData(read, conn)
 		if err != nil {
 			if globallock.IsRollbackError(err) {
-				return err
+				return diag.FromErr(err)
 			}
 			t.Fatal("Unexpected error:", err)
 		}
@@ -299,7 +287,7 @@ func TestUpdateConnection(t *testing.T) {
 
 		return nil
 	})
-	if err != nil {
-		t.Fatal(err)
+	if diags.HasError() {
+		t.Fatal(diags)
 	}
 }
@@ -63,7 +63,7 @@ func TestAccExasolConnection_import(t *testing.T) {
 
 	dbName := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	createConnection := func() {
@@ -136,7 +136,7 @@ func testExists(p *schema.Provider, id string) resource.TestCheckFunc {
 		}
 
 		return test.True(p, func(c internal.Conn) (bool, error) {
-			return connection.Exists(c, actualName)
+			return connection.Exists(c, actualName, false)
 		})(state)
 	}
 }
@@ -144,6 +144,6 @@ func testExists(p *schema.Provider, id string) resource.TestCheckFunc {
 func testExistsNotByName(p *schema.Provider, actualName string) resource.TestCheckFunc {
 
 	return test.False(p, func(c internal.Conn) (bool, error) {
-		return connection.Exists(c, actualName)
+		return connection.Exists(c, actualName, false)
 	})
 }
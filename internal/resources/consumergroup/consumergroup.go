@@ -0,0 +1,329 @@
+package consumergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	limitReg = regexp.MustCompile(`^(?i)(OFF|\d+[KMGT]?)$`)
+
+	// ramLimits in order of the columns of EXA_CONSUMER_GROUPS
+	ramLimits = []string{
+		"group_temp_db_ram_limit",
+		"user_temp_db_ram_limit",
+		"session_temp_db_ram_limit",
+	}
+)
+
+// Resource for Exasol Consumer Group
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Consumer Group",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"precedence": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Precedence of the Consumer Group between 1 and 1000",
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			"cpu_weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Share of CPU resources between 1 and 1000",
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			"group_temp_db_ram_limit":   ramLimitSchema("Limit of temporary DB RAM for the whole Consumer Group"),
+			"user_temp_db_ram_limit":    ramLimitSchema("Limit of temporary DB RAM per User"),
+			"session_temp_db_ram_limit": ramLimitSchema("Limit of temporary DB RAM per Session"),
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func ramLimitSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		Description:      description + ". Size like 10G or OFF",
+		ValidateFunc:     validation.StringMatch(limitReg, "limit has to be a size like 512M or OFF"),
+		DiffSuppressFunc: suppressLimitDiff,
+	}
+}
+
+func suppressLimitDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, err := parseLimit(old)
+	if err != nil {
+		return false
+	}
+	n, err := parseLimit(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// parseLimit converts a size like 10G into bytes. OFF is 0.
+func parseLimit(limit string) (int64, error) {
+	l := strings.ToUpper(strings.TrimSpace(limit))
+	if l == "OFF" {
+		return 0, nil
+	}
+	if !limitReg.MatchString(l) {
+		return 0, fmt.Errorf("invalid limit %s", limit)
+	}
+	var shift uint
+	switch l[len(l)-1] {
+	case 'K':
+		shift = 10
+	case 'M':
+		shift = 20
+	case 'G':
+		shift = 30
+	case 'T':
+		shift = 40
+	}
+	if shift != 0 {
+		l = l[:len(l)-1]
+	}
+	n, err := strconv.ParseInt(l, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n << shift, nil
+}
+
+// formatLimit converts a limit in MiB as stored in EXA_CONSUMER_GROUPS
+func formatLimit(v interface{}) string {
+	mib, ok := v.(float64)
+	if !ok || mib == 0 {
+		return "OFF"
+	}
+	return fmt.Sprintf("%dM", int64(mib))
+}
+
+// assignments returns the changed attributes in the form of a WITH or
+// SET clause
+func assignments(d internal.Data, all bool) []string {
+	a := []string{}
+	for _, key := range []string{"precedence", "cpu_weight"} {
+		v, _ := d.Get(key).(int)
+		if v == 0 || (!all && !d.HasChange(key)) {
+			continue
+		}
+		a = append(a, fmt.Sprintf("%s = %s", strings.ToUpper(key), quote.Literal(strconv.Itoa(v))))
+	}
+	for _, key := range ramLimits {
+		v, _ := d.Get(key).(string)
+		if v == "" || (!all && !d.HasChange(key)) {
+			continue
+		}
+		a = append(a, fmt.Sprintf("%s = %s", strings.ToUpper(key), quote.Literal(strings.ToUpper(v))))
+	}
+	return a
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConsumerGroup, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+
+	quoted := argument.QuotedIdentifier(d)
+	stmt := fmt.Sprintf("CREATE CONSUMER GROUP %s", quote.Name(name, quoted))
+	a := assignments(d, true)
+	if len(a) != 0 {
+		stmt += " WITH " + strings.Join(a, ", ")
+	}
+	_, err = c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConsumerGroup, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("DROP CONSUMER GROUP %s", quote.Name(name, argument.QuotedIdentifier(d)))
+	_, err = c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("name", quote.Canonical(name, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+	d.SetId(resource.NewGlobalID(name, quoted))
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Consumer Group %s not found", name)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.FetchSlice("SELECT PRECEDENCE, CPU_WEIGHT, GROUP_TEMP_DB_RAM_LIMIT, USER_TEMP_DB_RAM_LIMIT, SESSION_TEMP_DB_RAM_LIMIT FROM EXA_CONSUMER_GROUPS WHERE CONSUMER_GROUP_NAME = ?", []interface{}{
+		quote.Canonical(name, argument.QuotedIdentifier(d)),
+	}, "SYS")
+	if err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		// Consumer Group was dropped outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	precedence, _ := res[0][0].(float64)
+	err = d.Set("precedence", int(precedence))
+	if err != nil {
+		return err
+	}
+	weight, _ := res[0][1].(float64)
+	err = d.Set("cpu_weight", int(weight))
+	if err != nil {
+		return err
+	}
+	for i, key := range ramLimits {
+		err = d.Set(key, formatLimit(res[0][2+i]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassConsumerGroup, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn))
+	})
+}
+
+func updateData(d internal.Data, c internal.Conn) error {
+	quoted := argument.QuotedIdentifier(d)
+
+	if d.HasChange("name") {
+		old, new := d.GetChange("name")
+
+		err := db.RenameGlobal(c, "CONSUMER GROUP", old.(string), new.(string), quoted)
+		if err != nil {
+			return err
+		}
+		d.SetId(resource.NewGlobalID(new.(string), quoted))
+	}
+
+	a := assignments(d, false)
+	if len(a) == 0 {
+		return nil
+	}
+	stmt := fmt.Sprintf("ALTER CONSUMER GROUP %s SET %s", quote.Name(d.Get("name").(string), quoted), strings.Join(a, ", "))
+	_, err := c.Execute(stmt)
+	return err
+}
@@ -0,0 +1,96 @@
+package consumergroup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int64{
+		"OFF":  0,
+		"off":  0,
+		"1024": 1024,
+		"512M": 512 << 20,
+		"10g":  10 << 30,
+		"2T":   2 << 40,
+	}
+	for limit, expected := range tests {
+		actual, err := parseLimit(limit)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", limit, err)
+		}
+		if actual != expected {
+			t.Errorf("Expected %s to be %d: %d", limit, expected, actual)
+		}
+	}
+
+	_, err := parseLimit("10 GB")
+	if err == nil {
+		t.Fatal("Expected error for invalid limit")
+	}
+}
+
+func TestCreateUpdateConsumerGroup(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":                    name,
+			"precedence":              300,
+			"cpu_weight":              200,
+			"group_temp_db_ram_limit": "1G",
+		},
+	}
+	err := createData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer deleteData(d, locked.Conn)
+
+	update := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":       name,
+			"cpu_weight": 200,
+		},
+		NewValues: map[string]interface{}{
+			"name":       name,
+			"cpu_weight": 400,
+		},
+	}
+	err = updateData(update, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name": name,
+		},
+	}
+	read.SetId(d.Id())
+	err = readData(read, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if read.Id() == "" {
+		t.Fatal("Expected Consumer Group to exist")
+	}
+	if read.Get("precedence") != 300 {
+		t.Fatalf("Expected precedence 300: %v", read.Get("precedence"))
+	}
+	if read.Get("cpu_weight") != 400 {
+		t.Fatalf("Expected cpu_weight 400: %v", read.Get("cpu_weight"))
+	}
+	if read.Get("group_temp_db_ram_limit") != "1024M" {
+		t.Fatalf("Expected group_temp_db_ram_limit 1024M: %v", read.Get("group_temp_db_ram_limit"))
+	}
+}
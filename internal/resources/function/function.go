@@ -0,0 +1,316 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	Parameter = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of Parameter",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "SQL type of Parameter",
+			},
+		},
+	}
+)
+
+// Resource for Exasol Function
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Function",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"schema": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Schema to create Function in",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"parameter": {
+				Type:        schema.TypeList,
+				Elem:        Parameter,
+				Optional:    true,
+				Description: "Parameters passed to the Function",
+			},
+			"returns": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "SQL type returned by the Function",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Declarations and BEGIN ... END block following IS",
+				DiffSuppressFunc: argument.SuppressBodyDiff,
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn, ra, false))
+	})...)
+}
+
+func parameters(d internal.Data) []statements.FunctionParameter {
+	listiface, ok := d.Get("parameter").([]interface{})
+	if !ok {
+		return nil
+	}
+	params := make([]statements.FunctionParameter, 0, len(listiface))
+	for _, paramiface := range listiface {
+		p := paramiface.(map[string]interface{})
+		params = append(params, statements.FunctionParameter{
+			Name: p["name"].(string),
+			Type: p["type"].(string),
+		})
+	}
+	return params
+}
+
+func createData(d internal.Data, c internal.Conn, args argument.RequiredArguments, replace bool) error {
+
+	quoted := argument.QuotedIdentifier(d)
+	cf := statements.CreateFunction{
+		Schema:     args.Schema,
+		Name:       args.Name,
+		Parameters: parameters(d),
+		Returns:    d.Get("returns").(string),
+		Body:       d.Get("body").(string),
+		Replace:    replace,
+		Quoted:     quoted,
+	}
+
+	err := cf.Execute(c)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn, ra))
+	})...)
+}
+
+func deleteData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
+
+	df := statements.DropFunction{
+		Schema: args.Schema,
+		Name:   args.Name,
+		Quoted: argument.QuotedIdentifier(d),
+	}
+	err := df.Execute(c)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	id := d.Id()
+
+	m, quoted, err := resource.SplitQuotedID(id, d.Get("schema").(string))
+	if err != nil {
+		return err
+	}
+
+	if len(strings.TrimSpace(m.Schema)) == 0 {
+		return errors.New("missing schema in import")
+	}
+
+	err = d.Set("name", m.ObjectName)
+	if err != nil {
+		return err
+	}
+	err = d.Set("schema", m.Schema)
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+
+	diags := readData(d, c, argument.RequiredArguments{
+		Schema: m.Schema,
+		Name:   m.ObjectName,
+	})
+	if diags.HasError() {
+		return fmt.Errorf("Function %s not importable: %s", id, diags[0].Summary)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, readData(d, locked.Conn, ra)...)
+}
+
+func readData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
+
+	quoted := argument.QuotedIdentifier(d)
+	res, err := c.FetchSlice("SELECT FUNCTION_TEXT FROM EXA_ALL_FUNCTIONS WHERE FUNCTION_SCHEMA = ? AND FUNCTION_NAME = ?", []interface{}{
+		quote.Canonical(args.Schema, quoted),
+		quote.Canonical(args.Name, quoted),
+	}, "SYS")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(res) == 0 {
+		return diag.Errorf("Function %s.%s not found", args.Schema, args.Name)
+	}
+
+	text, _ := res[0][0].(string)
+	ft, err := statements.ParseFunctionText(text)
+	if err != nil {
+		return diag.Errorf("Function %s.%s has unexpected text: %s", args.Schema, args.Name, err)
+	}
+
+	err = d.Set("parameter", parameterList(ft.Parameters, parameters(d), quoted))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("returns", ft.Returns)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("body", ft.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
+	return nil
+}
+
+// parameterList converts params to the form of parameter. Names
+// folded by Exasol keep their configured spelling.
+func parameterList(params, configured []statements.FunctionParameter, quoted bool) []interface{} {
+	l := make([]interface{}, 0, len(params))
+	for i, p := range params {
+		if !quoted && i < len(configured) && strings.EqualFold(configured[i].Name, p.Name) {
+			p.Name = configured[i].Name
+		}
+		l = append(l, map[string]interface{}{
+			"name": p.Name,
+			"type": p.Type,
+		})
+	}
+	return l
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn, ra))
+	})...)
+}
+
+func updateData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
+
+	if d.HasChange("name") {
+		old, new := d.GetChange("name")
+
+		err := db.Rename(c, "FUNCTION", old.(string), new.(string), args.Schema, argument.QuotedIdentifier(d))
+		if err != nil {
+			return err
+		}
+	}
+
+	replaceNecessary := d.HasChange("parameter") || d.HasChange("returns") || d.HasChange("body")
+	if replaceNecessary {
+		return createData(d, c, argument.RequiredArguments{
+			Schema: args.Schema,
+			Name:   d.Get("name").(string),
+		}, true)
+	}
+
+	d.SetId(resource.NewQuotedID(args.Schema, d.Get("name").(string), argument.QuotedIdentifier(d)))
+	return nil
+}
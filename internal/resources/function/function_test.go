@@ -0,0 +1,83 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+)
+
+func TestCreateAndRead(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	args := argument.RequiredArguments{
+		Schema: schemaName,
+		Name:   name,
+	}
+	body := fmt.Sprintf("BEGIN\n  RETURN x * 2;\nEND %s;", name)
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	create := &internal.TestData{
+		Values: map[string]interface{}{
+			"parameter": []interface{}{
+				map[string]interface{}{
+					"name": "x",
+					"type": "DOUBLE",
+				},
+			},
+			"returns": "DOUBLE",
+			"body":    body,
+		},
+	}
+	err := createData(create, locked.Conn, args, false)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	diags := readData(read, locked.Conn, args)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	if !strings.EqualFold(read.Get("returns").(string), "DOUBLE") {
+		t.Fatalf("Unexpected return type: %s", read.Get("returns"))
+	}
+	if strings.TrimSpace(read.Get("body").(string)) != body {
+		t.Fatalf("Unexpected body: %s", read.Get("body"))
+	}
+	params := read.Get("parameter").([]interface{})
+	if len(params) != 1 || !strings.EqualFold(params[0].(map[string]interface{})["name"].(string), "x") {
+		t.Fatalf("Unexpected parameters: %v", params)
+	}
+}
+
+func TestParameterList(t *testing.T) {
+	t.Parallel()
+
+	params := []statements.FunctionParameter{
+		{Name: "X", Type: "DOUBLE"},
+		{Name: "Y", Type: "DOUBLE"},
+	}
+	configured := []statements.FunctionParameter{
+		{Name: "x", Type: "DOUBLE"},
+	}
+
+	l := parameterList(params, configured, false)
+	if l[0].(map[string]interface{})["name"] != "x" || l[1].(map[string]interface{})["name"] != "Y" {
+		t.Fatalf("Unexpected parameters: %v", l)
+	}
+
+	l = parameterList(params, configured, true)
+	if l[0].(map[string]interface{})["name"] != "X" {
+		t.Fatalf("Expected quoted name as stored: %v", l)
+	}
+}
@@ -0,0 +1,432 @@
+package granteeprivileges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/objectprivilege"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/systemprivilege"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	ObjectPrivilege = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"privilege": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "One of SELECT, INSERT, UPDATE, DELETE, ALTER, EXECUTE, REFERENCES or ACCESS",
+				ValidateFunc: validation.StringInSlice([]string{"SELECT", "INSERT", "UPDATE", "DELETE", "ALTER", "EXECUTE", "REFERENCES", "ACCESS"}, true),
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "One of SCHEMA, TABLE, VIEW, FUNCTION, SCRIPT or CONNECTION",
+				ValidateFunc: validation.StringInSlice([]string{"SCHEMA", "TABLE", "VIEW", "FUNCTION", "SCRIPT", "CONNECTION"}, true),
+			},
+			"object": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of object. Objects inside a Schema are qualified as in SCHEMA.NAME",
+			},
+		},
+	}
+)
+
+// Resource for authoritatively managing all privileges of a User or Role.
+// Privileges not declared are revoked.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role the privileges are granted to",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"system_privileges": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Complete set of system privileges like CREATE SESSION",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Complete set of granted Roles",
+			},
+			"object_privileges": {
+				Type:        schema.TypeSet,
+				Elem:        ObjectPrivilege,
+				Optional:    true,
+				Description: "Complete set of privileges on database objects",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+// privilegeSet maps the form of a privilege used in GRANT and REVOKE to
+// its value in Terraform
+type privilegeSet map[string]interface{}
+
+// keys returns the sorted keys so statements are issued deterministically
+func (s privilegeSet) keys() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s privilegeSet) list() []interface{} {
+	l := make([]interface{}, 0, len(s))
+	for _, k := range s.keys() {
+		l = append(l, s[k])
+	}
+	return l
+}
+
+// privileges groups privileges by kind
+type privileges struct {
+	system  privilegeSet
+	roles   privilegeSet
+	objects privilegeSet
+}
+
+func setList(d internal.Data, key string) []interface{} {
+	switch v := d.Get(key).(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func objectKey(privilege, objectType, object string) (string, error) {
+	return objectprivilege.Target(privilege, objectType, object)
+}
+
+// grantedObjectKey builds the key of a privilege read from EXA_DBA_OBJ_PRIVS
+// in the form objectKey produces. It does not validate, so privileges on
+// object types not supported in declarations can still be revoked.
+func grantedObjectKey(privilege, objectType, object string) string {
+	if privilege == "CONNECTION" {
+		return fmt.Sprintf("CONNECTION %s", object)
+	}
+	return fmt.Sprintf("%s ON %s %s", privilege, objectType, object)
+}
+
+// declared returns the privileges declared in d
+func declared(d internal.Data) (privileges, error) {
+	quoted := argument.QuotedIdentifier(d)
+	p := privileges{
+		system:  privilegeSet{},
+		roles:   privilegeSet{},
+		objects: privilegeSet{},
+	}
+	for _, v := range setList(d, "system_privileges") {
+		p.system[systemprivilege.Normalize(v.(string))] = v
+	}
+	for _, v := range setList(d, "roles") {
+		p.roles[quote.Name(v.(string), quoted)] = v
+	}
+	for _, v := range setList(d, "object_privileges") {
+		m := v.(map[string]interface{})
+		k, err := objectKey(m["privilege"].(string), m["object_type"].(string), m["object"].(string))
+		if err != nil {
+			return privileges{}, err
+		}
+		p.objects[k] = m
+	}
+	return p, nil
+}
+
+// granted fetches the privileges currently granted. Values keep the
+// spelling of declared privileges where they match.
+func granted(d internal.Data, c internal.Conn, decl privileges) (privileges, error) {
+	grantee := quote.Canonical(d.Get("grantee").(string), argument.QuotedIdentifier(d))
+	p := privileges{
+		system:  privilegeSet{},
+		roles:   privilegeSet{},
+		objects: privilegeSet{},
+	}
+
+	res, err := c.FetchSlice("SELECT PRIVILEGE FROM EXA_DBA_SYS_PRIVS WHERE GRANTEE = ?", []interface{}{
+		grantee,
+	}, "SYS")
+	if err != nil {
+		return privileges{}, err
+	}
+	for _, row := range res {
+		name := row[0].(string)
+		k := systemprivilege.Normalize(name)
+		p.system[k] = declaredOr(decl.system, k, name)
+	}
+
+	res, err = c.FetchSlice("SELECT GRANTED_ROLE FROM EXA_DBA_ROLE_PRIVS WHERE GRANTEE = ?", []interface{}{
+		grantee,
+	}, "SYS")
+	if err != nil {
+		return privileges{}, err
+	}
+	for _, row := range res {
+		name := row[0].(string)
+		if name == "PUBLIC" {
+			// Every User implicitly has PUBLIC, which cannot be revoked
+			continue
+		}
+		k := quote.Identifier(name)
+		p.roles[k] = declaredOr(decl.roles, k, name)
+	}
+
+	res, err = c.FetchSlice("SELECT PRIVILEGE, OBJECT_TYPE, OBJECT_SCHEMA, OBJECT_NAME FROM EXA_DBA_OBJ_PRIVS WHERE GRANTEE = ?", []interface{}{
+		grantee,
+	}, "SYS")
+	if err != nil {
+		return privileges{}, err
+	}
+	for _, row := range res {
+		privilege := row[0].(string)
+		objectType := row[1].(string)
+		objectSchema, _ := row[2].(string)
+		object := quote.Identifier(row[3].(string))
+		if objectSchema != "" {
+			object = quote.Qualified(objectSchema, row[3].(string), true)
+		}
+		k := grantedObjectKey(privilege, objectType, object)
+		p.objects[k] = declaredOr(decl.objects, k, map[string]interface{}{
+			"privilege":   privilege,
+			"object_type": objectType,
+			"object":      object,
+		})
+	}
+	return p, nil
+}
+
+func declaredOr(decl privilegeSet, k string, v interface{}) interface{} {
+	if dv, ok := decl[k]; ok {
+		return dv
+	}
+	return v
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	err := applyData(d, c)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGlobalID(d.Get("grantee").(string), argument.QuotedIdentifier(d)))
+	return nil
+}
+
+// applyData revokes all privileges not declared and grants all
+// declared ones missing
+func applyData(d internal.Data, c internal.Conn) error {
+	decl, err := declared(d)
+	if err != nil {
+		return err
+	}
+	actual, err := granted(d, c, decl)
+	if err != nil {
+		return err
+	}
+
+	grantee := quote.Name(d.Get("grantee").(string), argument.QuotedIdentifier(d))
+	for _, pair := range [][2]privilegeSet{
+		{actual.system, decl.system},
+		{actual.roles, decl.roles},
+		{actual.objects, decl.objects},
+	} {
+		err = revokeMissing(c, grantee, pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+	}
+	for _, pair := range [][2]privilegeSet{
+		{decl.system, actual.system},
+		{decl.roles, actual.roles},
+		{decl.objects, actual.objects},
+	} {
+		err = grantMissing(c, grantee, pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeMissing revokes everything in from which is not in keep
+func revokeMissing(c internal.Conn, grantee string, from, keep privilegeSet) error {
+	for _, k := range from.keys() {
+		if _, ok := keep[k]; ok {
+			continue
+		}
+		_, err := c.Execute(fmt.Sprintf("REVOKE %s FROM %s", k, grantee))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantMissing grants everything in want which is not in have
+func grantMissing(c internal.Conn, grantee string, want, have privilegeSet) error {
+	for _, k := range want.keys() {
+		if _, ok := have[k]; ok {
+			continue
+		}
+		_, err := c.Execute(fmt.Sprintf("GRANT %s TO %s", k, grantee))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(applyData(d, conn))
+	})
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+// deleteData revokes all declared privileges
+func deleteData(d internal.Data, c internal.Conn) error {
+	decl, err := declared(d)
+	if err != nil {
+		return err
+	}
+	actual, err := granted(d, c, decl)
+	if err != nil {
+		return err
+	}
+
+	grantee := quote.Name(d.Get("grantee").(string), argument.QuotedIdentifier(d))
+	for _, pair := range [][2]privilegeSet{
+		{decl.system, actual.system},
+		{decl.roles, actual.roles},
+		{decl.objects, actual.objects},
+	} {
+		for _, k := range pair[0].keys() {
+			if _, ok := pair[1][k]; !ok {
+				continue
+			}
+			_, err := c.Execute(fmt.Sprintf("REVOKE %s FROM %s", k, grantee))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	grantee, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("grantee", quote.Canonical(grantee, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+	d.SetId(resource.NewGlobalID(grantee, quoted))
+	return readData(d, c)
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+// readData stores all currently granted privileges, so a plan shows
+// undeclared privileges as to be revoked
+func readData(d internal.Data, c internal.Conn) error {
+	decl, err := declared(d)
+	if err != nil {
+		return err
+	}
+	actual, err := granted(d, c, decl)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("system_privileges", actual.system.list())
+	if err != nil {
+		return err
+	}
+	err = d.Set("roles", actual.roles.list())
+	if err != nil {
+		return err
+	}
+	return d.Set("object_privileges", actual.objects.list())
+}
@@ -0,0 +1,135 @@
+package granteeprivileges
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestDeclared(t *testing.T) {
+	t.Parallel()
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":           "analyst",
+			"system_privileges": []interface{}{"create  session"},
+			"roles":             []interface{}{"reader"},
+			"object_privileges": []interface{}{
+				map[string]interface{}{
+					"privilege":   "select",
+					"object_type": "table",
+					"object":      "sales.orders",
+				},
+			},
+		},
+	}
+
+	p, err := declared(d)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if _, ok := p.system["CREATE SESSION"]; !ok {
+		t.Fatalf("Unexpected system privileges: %v", p.system)
+	}
+	if _, ok := p.roles[`"READER"`]; !ok {
+		t.Fatalf("Unexpected roles: %v", p.roles)
+	}
+	if _, ok := p.objects[`SELECT ON TABLE "SALES"."ORDERS"`]; !ok {
+		t.Fatalf("Unexpected object privileges: %v", p.objects)
+	}
+}
+
+func TestApplyRevokesUndeclared(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE ROLE %s", name),
+		fmt.Sprintf("GRANT CREATE TABLE TO %s", name),
+	} {
+		_, err := locked.Conn.Execute(stmt)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer locked.Conn.Execute(fmt.Sprintf("DROP ROLE %s", name))
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":           name,
+			"system_privileges": []interface{}{"CREATE SESSION"},
+		},
+	}
+
+	err := readData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	state := d.Get("system_privileges").([]interface{})
+	if len(state) != 1 || state[0] != "CREATE TABLE" {
+		t.Fatalf("Expected undeclared privilege in state: %v", state)
+	}
+
+	d.Set("system_privileges", []interface{}{"CREATE SESSION"})
+	err = createData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	err = readData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	state = d.Get("system_privileges").([]interface{})
+	if len(state) != 1 || state[0] != "CREATE SESSION" {
+		t.Fatalf("Expected only declared privilege: %v", state)
+	}
+}
+
+func TestApplyRevokesUnknownObjectType(t *testing.T) {
+	t.Parallel()
+
+	c := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			"SELECT PRIVILEGE, OBJECT_TYPE, OBJECT_SCHEMA, OBJECT_NAME FROM EXA_DBA_OBJ_PRIVS WHERE GRANTEE = ?": {
+				{"SELECT", "TABLE", "SALES", "ORDERS"},
+				{"SELECT", "VIRTUAL SCHEMA", nil, "VS"},
+			},
+		},
+	}
+	d := &internal.TestData{
+		NewValues: map[string]interface{}{
+			"grantee": "analyst",
+			"object_privileges": []interface{}{
+				map[string]interface{}{
+					"privilege":   "select",
+					"object_type": "table",
+					"object":      "sales.orders",
+				},
+			},
+		},
+	}
+
+	err := readData(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	state := d.Values["object_privileges"].([]interface{})
+	if len(state) != 2 {
+		t.Fatalf("Expected undeclared privilege in state: %v", state)
+	}
+
+	c.Statements = nil
+	err = applyData(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := `REVOKE SELECT ON VIRTUAL SCHEMA "VS" FROM "ANALYST"`
+	if len(c.Statements) != 4 || c.Statements[3] != expected {
+		t.Fatalf("Expected %s: %v", expected, c.Statements)
+	}
+}
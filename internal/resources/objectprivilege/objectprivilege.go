@@ -0,0 +1,393 @@
+package objectprivilege
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// privilegeAccess restricts the use of a Connection, optionally to a Script
+	privilegeAccess = "ACCESS"
+	// privilegeConnection allows using a Connection as in GRANT CONNECTION
+	privilegeConnection = "CONNECTION"
+)
+
+var (
+	privileges  = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "ALTER", "EXECUTE", "REFERENCES", privilegeAccess, privilegeConnection}
+	objectTypes = []string{"SCHEMA", "TABLE", "VIEW", "FUNCTION", "SCRIPT", "CONNECTION"}
+
+	grantedReg = regexp.MustCompile(`^(\w+) ON (\w+) (.+?)(?: FOR SCRIPT (.+))?$`)
+)
+
+// Resource for granting a privilege on a database object to a User or Role
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role to grant the privilege to",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"privilege": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "One of SELECT, INSERT, UPDATE, DELETE, ALTER, EXECUTE, REFERENCES, ACCESS or CONNECTION",
+				ValidateFunc:     validation.StringInSlice(privileges, true),
+				StateFunc:        argument.UpperCase,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"object_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "One of SCHEMA, TABLE, VIEW, FUNCTION, SCRIPT or CONNECTION",
+				ValidateFunc:     validation.StringInSlice(objectTypes, true),
+				StateFunc:        argument.UpperCase,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"object": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Name of object. Objects inside a Schema are qualified as in SCHEMA.NAME, which allows for using ids of other resources",
+				DiffSuppressFunc: suppressObjectDiff,
+			},
+			"for_script": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Restricts ACCESS on a Connection to the Script qualified as in SCHEMA.NAME",
+				DiffSuppressFunc: suppressObjectDiff,
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func suppressObjectDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldObject, err := parseObject(old)
+	if err != nil {
+		return false
+	}
+	newObject, err := parseObject(new)
+	if err != nil {
+		return false
+	}
+	return oldObject == newObject
+}
+
+// object is a database object privileges are granted on
+type object struct {
+	Schema string
+	Name   string
+	Quoted bool
+}
+
+// parseObject parses a possibly qualified object name the same way ids of
+// other resources are parsed
+func parseObject(qn string) (object, error) {
+	m, quoted, err := resource.SplitQuotedID(qn, "")
+	if err != nil {
+		return object{}, err
+	}
+	o := object{
+		Schema: quote.Canonical(m.Schema, quoted),
+		Name:   quote.Canonical(m.ObjectName, quoted),
+		Quoted: quoted,
+	}
+	return o, nil
+}
+
+// identifier returns o as identifier usable in statements
+func (o object) identifier() string {
+	if o.Schema == "" {
+		return quote.Identifier(o.Name)
+	}
+	return quote.Qualified(o.Schema, o.Name, true)
+}
+
+// grant describes a privilege as passed to GRANT and REVOKE
+type grant struct {
+	grantee    string
+	privilege  string
+	objectType string
+	object     object
+	forScript  *object
+}
+
+func grantFromData(d internal.Data) (grant, error) {
+	forScript, _ := d.Get("for_script").(string)
+	return newGrant(quote.Name(d.Get("grantee").(string), argument.QuotedIdentifier(d)), d.Get("privilege").(string), d.Get("object_type").(string), d.Get("object").(string), forScript)
+}
+
+func newGrant(grantee, privilege, objectType, objectName, forScript string) (grant, error) {
+	g := grant{
+		grantee:    grantee,
+		privilege:  strings.ToUpper(privilege),
+		objectType: strings.ToUpper(objectType),
+	}
+
+	var err error
+	g.object, err = parseObject(objectName)
+	if err != nil {
+		return grant{}, err
+	}
+
+	globalObject := g.objectType == "SCHEMA" || g.objectType == "CONNECTION"
+	if globalObject && g.object.Schema != "" {
+		return grant{}, fmt.Errorf("%s %s cannot be qualified", g.objectType, objectName)
+	}
+	if !globalObject && g.object.Schema == "" {
+		return grant{}, fmt.Errorf("%s %s has to be qualified by its Schema", g.objectType, objectName)
+	}
+
+	connectionOnly := g.privilege == privilegeAccess || g.privilege == privilegeConnection
+	if connectionOnly && g.objectType != "CONNECTION" {
+		return grant{}, fmt.Errorf("%s is only granted on CONNECTION", g.privilege)
+	}
+
+	if forScript != "" {
+		if g.privilege != privilegeAccess {
+			return grant{}, errors.New("for_script is only allowed with ACCESS")
+		}
+		script, err := parseObject(forScript)
+		if err != nil {
+			return grant{}, err
+		}
+		if script.Schema == "" {
+			return grant{}, fmt.Errorf("Script %s has to be qualified by its Schema", forScript)
+		}
+		g.forScript = &script
+	}
+	return g, nil
+}
+
+// target returns what the privilege is granted on as in
+// SELECT ON TABLE "S"."T"
+func (g grant) target() string {
+	if g.privilege == privilegeConnection {
+		return fmt.Sprintf("CONNECTION %s", g.object.identifier())
+	}
+	t := fmt.Sprintf("%s ON %s %s", g.privilege, g.objectType, g.object.identifier())
+	if g.forScript != nil {
+		t = fmt.Sprintf("%s FOR SCRIPT %s", t, g.forScript.identifier())
+	}
+	return t
+}
+
+// Target returns privilege on the possibly qualified object as used in
+// GRANT and REVOKE. Equal targets denote the same privilege.
+func Target(privilege, objectType, object string) (string, error) {
+	g, err := newGrant("", privilege, objectType, object, "")
+	if err != nil {
+		return "", err
+	}
+	return g.target(), nil
+}
+
+func (g grant) grantStatement() string {
+	return fmt.Sprintf("GRANT %s TO %s", g.target(), g.grantee)
+}
+
+func (g grant) revokeStatement() string {
+	return fmt.Sprintf("REVOKE %s FROM %s", g.target(), g.grantee)
+}
+
+// granted returns the granted part of the id
+func (g grant) granted(d internal.Data) string {
+	t := fmt.Sprintf("%s ON %s %s", g.privilege, g.objectType, d.Get("object").(string))
+	forScript, _ := d.Get("for_script").(string)
+	if forScript != "" {
+		t = fmt.Sprintf("%s FOR SCRIPT %s", t, forScript)
+	}
+	return t
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	g, err := grantFromData(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Execute(g.grantStatement())
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGrantID(d.Get("grantee").(string), argument.QuotedIdentifier(d), g.granted(d)))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	g, err := grantFromData(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Execute(g.revokeStatement())
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	grantee, quoted, granted, err := resource.SplitGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+	m := grantedReg.FindStringSubmatch(granted)
+	if m == nil {
+		return fmt.Errorf("%s is not of form PRIVILEGE ON TYPE OBJECT [FOR SCRIPT SCRIPT]", granted)
+	}
+
+	values := map[string]interface{}{
+		"grantee":           quote.Canonical(grantee, quoted),
+		"quoted_identifier": quoted,
+		"privilege":         strings.ToUpper(m[1]),
+		"object_type":       strings.ToUpper(m[2]),
+		"object":            m[3],
+		"for_script":        m[4],
+	}
+	for k, v := range values {
+		err = d.Set(k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("%s not granted to %s", granted, grantee)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	g, err := grantFromData(d)
+	if err != nil {
+		return err
+	}
+
+	grantee := quote.Canonical(d.Get("grantee").(string), argument.QuotedIdentifier(d))
+
+	var res [][]interface{}
+	switch {
+	case g.privilege == privilegeConnection:
+		res, err = c.FetchSlice("SELECT GRANTEE FROM EXA_DBA_CONNECTION_PRIVS WHERE GRANTEE = ? AND GRANTED_CONNECTION = ?", []interface{}{
+			grantee,
+			g.object.Name,
+		}, "SYS")
+	case g.forScript != nil:
+		res, err = c.FetchSlice(`SELECT GRANTEE FROM EXA_DBA_RESTRICTED_OBJ_PRIVS
+WHERE GRANTEE = ? AND PRIVILEGE = ? AND OBJECT_TYPE = ? AND OBJECT_NAME = ?
+AND FOR_OBJECT_TYPE = 'SCRIPT' AND FOR_OBJECT_SCHEMA = ? AND FOR_OBJECT_NAME = ?`, []interface{}{
+			grantee,
+			g.privilege,
+			g.objectType,
+			g.object.Name,
+			g.forScript.Schema,
+			g.forScript.Name,
+		}, "SYS")
+	case g.object.Schema == "":
+		res, err = c.FetchSlice("SELECT GRANTEE FROM EXA_DBA_OBJ_PRIVS WHERE GRANTEE = ? AND PRIVILEGE = ? AND OBJECT_TYPE = ? AND OBJECT_NAME = ?", []interface{}{
+			grantee,
+			g.privilege,
+			g.objectType,
+			g.object.Name,
+		}, "SYS")
+	default:
+		res, err = c.FetchSlice("SELECT GRANTEE FROM EXA_DBA_OBJ_PRIVS WHERE GRANTEE = ? AND PRIVILEGE = ? AND OBJECT_TYPE = ? AND OBJECT_SCHEMA = ? AND OBJECT_NAME = ?", []interface{}{
+			grantee,
+			g.privilege,
+			g.objectType,
+			g.object.Schema,
+			g.object.Name,
+		}, "SYS")
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		// Privilege was revoked outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(resource.NewGrantID(d.Get("grantee").(string), argument.QuotedIdentifier(d), g.granted(d)))
+	return nil
+}
@@ -0,0 +1,174 @@
+package objectprivilege
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestGrantStatements(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		values map[string]interface{}
+		grant  string
+		revoke string
+	}{
+		{
+			values: map[string]interface{}{
+				"grantee":     "analyst",
+				"privilege":   "select",
+				"object_type": "table",
+				"object":      "SALES.ORDERS",
+			},
+			grant:  `GRANT SELECT ON TABLE "SALES"."ORDERS" TO "ANALYST"`,
+			revoke: `REVOKE SELECT ON TABLE "SALES"."ORDERS" FROM "ANALYST"`,
+		},
+		{
+			values: map[string]interface{}{
+				"grantee":     "analyst",
+				"privilege":   "SELECT",
+				"object_type": "SCHEMA",
+				"object":      `"Sales"`,
+			},
+			grant:  `GRANT SELECT ON SCHEMA "Sales" TO "ANALYST"`,
+			revoke: `REVOKE SELECT ON SCHEMA "Sales" FROM "ANALYST"`,
+		},
+		{
+			values: map[string]interface{}{
+				"grantee":     "etl",
+				"privilege":   "ACCESS",
+				"object_type": "CONNECTION",
+				"object":      "s3",
+				"for_script":  "udf.load",
+			},
+			grant:  `GRANT ACCESS ON CONNECTION "S3" FOR SCRIPT "UDF"."LOAD" TO "ETL"`,
+			revoke: `REVOKE ACCESS ON CONNECTION "S3" FOR SCRIPT "UDF"."LOAD" FROM "ETL"`,
+		},
+		{
+			values: map[string]interface{}{
+				"grantee":     "etl",
+				"privilege":   "CONNECTION",
+				"object_type": "CONNECTION",
+				"object":      "s3",
+			},
+			grant:  `GRANT CONNECTION "S3" TO "ETL"`,
+			revoke: `REVOKE CONNECTION "S3" FROM "ETL"`,
+		},
+	}
+
+	for _, c := range cases {
+		g, err := grantFromData(&internal.TestData{Values: c.values})
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if g.grantStatement() != c.grant {
+			t.Errorf("Unexpected grant: %s", g.grantStatement())
+		}
+		if g.revokeStatement() != c.revoke {
+			t.Errorf("Unexpected revoke: %s", g.revokeStatement())
+		}
+	}
+
+	_, err := grantFromData(&internal.TestData{Values: map[string]interface{}{
+		"grantee":     "analyst",
+		"privilege":   "SELECT",
+		"object_type": "TABLE",
+		"object":      "ORDERS",
+	}})
+	if err == nil {
+		t.Fatal("Expected error for unqualified Table")
+	}
+}
+
+var grantFuncs = internal.GrantFuncs{
+	Create: createData,
+	Read:   readData,
+	Delete: deleteData,
+}
+
+func TestGrantAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	defer internal.MustCreateRoles(t, locked.Conn, name)()
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE SCHEMA %s", name),
+		fmt.Sprintf("CREATE TABLE %s.T (A INT)", name),
+	} {
+		_, err := locked.Conn.Execute(stmt)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer locked.Conn.Execute(fmt.Sprintf("DROP SCHEMA %s CASCADE", name))
+
+	internal.MustGrantAndRevoke(t, locked.Conn, &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":     name,
+			"privilege":   "SELECT",
+			"object_type": "TABLE",
+			"object":      fmt.Sprintf("%s.T", name),
+		},
+	}, grantFuncs)
+}
+
+func TestGrantAccessForScript(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	defer internal.MustCreateRoles(t, locked.Conn, name)()
+	for _, stmt := range []string{
+		fmt.Sprintf("CREATE CONNECTION %s TO 'ftp://localhost'", name),
+		fmt.Sprintf("CREATE SCHEMA %s", name),
+		fmt.Sprintf("CREATE LUA SCALAR SCRIPT %s.LOADER () RETURNS INT AS\nfunction run(ctx) return 1 end", name),
+	} {
+		_, err := locked.Conn.Execute(stmt)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer locked.Conn.Execute(fmt.Sprintf("DROP SCHEMA %s CASCADE", name))
+	defer locked.Conn.Execute(fmt.Sprintf("DROP CONNECTION %s", name))
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":     name,
+			"privilege":   "ACCESS",
+			"object_type": "CONNECTION",
+			"object":      name,
+			"for_script":  fmt.Sprintf("%s.loader", name),
+		},
+	}
+	internal.MustGrantAndRevoke(t, locked.Conn, d, grantFuncs)
+
+	// Access for another Script is a different grant
+	err := createData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	other := &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":     name,
+			"privilege":   "ACCESS",
+			"object_type": "CONNECTION",
+			"object":      name,
+			"for_script":  fmt.Sprintf("%s.other", name),
+		},
+	}
+	other.SetId("other")
+	err = readData(other, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if other.Id() != "" {
+		t.Fatal("Expected grant for other Script to be missing")
+	}
+}
@@ -3,15 +3,17 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // PhysicalSchema returns the schema.Resource for managing a non-virtual Schema
@@ -19,64 +21,127 @@ func PhysicalSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Schema",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"owner": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "User or Role owning the Schema. Folded to upper case independent of quoted_identifier. Removing it keeps the current owner",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"comment": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of Schema",
+				Optional:    true,
+				Description: "Comment for the Schema",
+			},
+			"raw_size_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum raw size of the Schema in bytes. 0 means no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"raw_object_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Uncompressed size of all objects in the Schema in bytes",
+			},
+			"mem_object_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Compressed size of all objects in the Schema in bytes",
 			},
 		},
-		Create:      createPhysicalSchema,
-		ReadContext: readPhysicalSchema,
-		Update:      updatePhysicalSchema,
-		Delete:      deletePhysicalSchema,
+		CreateContext: createPhysicalSchema,
+		ReadContext:   readPhysicalSchema,
+		UpdateContext: updatePhysicalSchema,
+		DeleteContext: deletePhysicalSchema,
 		Importer: &schema.ResourceImporter{
-			State: importPhysicalSchema,
+			StateContext: importPhysicalSchema,
 		},
+		Timeouts: resource.Timeouts(),
 	}
 }
 
-func createPhysicalSchema(d *schema.ResourceData, meta interface{}) error {
+func createPhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	err := createPhysicalSchemaData(d, locked.Conn)
-	if err != nil {
-		return err
-	}
-	return locked.Conn.Commit()
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createPhysicalSchemaData(d, conn))
+	})
 }
 
-func createPhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
+func createPhysicalSchemaData(d internal.Data, c internal.Conn) error {
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	stmt := fmt.Sprintf("CREATE SCHEMA %s", name)
+	quoted := argument.QuotedIdentifier(d)
+	stmt := fmt.Sprintf("CREATE SCHEMA %s", quote.Name(name, quoted))
 	_, err = c.Execute(stmt)
 
 	if err != nil {
 		return err
 	}
 
-	d.SetId(strings.ToUpper(name))
+	owner, _ := argument.GetOkAsString(d, "owner")
+	if owner != "" {
+		err = changeSchemaOwner(c, name, owner, quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	comment, _ := argument.GetOkAsString(d, "comment")
+	if comment != "" {
+		err = db.Comment(c, "SCHEMA", name, comment, "", quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	limit, _ := d.Get("raw_size_limit").(int)
+	if limit != 0 {
+		err = setRawSizeLimit(c, name, limit, quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
 	return nil
 }
 
-func deletePhysicalSchema(d *schema.ResourceData, meta interface{}) error {
+// changeSchemaOwner transfers the Schema to owner. quoted only applies to
+// the Schema, owner is folded to upper case like an unquoted identifier.
+func changeSchemaOwner(c internal.Conn, name, owner string, quoted bool) error {
+	stmt := fmt.Sprintf("ALTER SCHEMA %s CHANGE OWNER %s", quote.Name(name, quoted), quote.RegularIdentifier(owner))
+	_, err := c.Execute(stmt)
+	return err
+}
+
+func setRawSizeLimit(c internal.Conn, name string, limit int, quoted bool) error {
+	stmt := fmt.Sprintf("ALTER SCHEMA %s SET RAW_SIZE_LIMIT = %d", quote.Name(name, quoted), limit)
+	_, err := c.Execute(stmt)
+	return err
+}
+
+func deletePhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	err := deletePhysicalSchemaData(d, locked.Conn)
-	if err != nil {
-		return err
-	}
-	return locked.Conn.Commit()
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deletePhysicalSchemaData(d, conn))
+	})
 }
 
-func deletePhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
+func deletePhysicalSchemaData(d internal.Data, c internal.Conn) error {
 	name := d.Get("name").(string)
 
-	stmt := fmt.Sprintf("DROP SCHEMA %s", name)
+	stmt := fmt.Sprintf("DROP SCHEMA %s", quote.Name(name, argument.QuotedIdentifier(d)))
 	_, err := c.Execute(stmt)
 	if err != nil {
 		return err
@@ -86,11 +151,14 @@ func deletePhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
 	return nil
 }
 
-func importPhysicalSchema(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func importPhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importPhysicalSchemaData(d, locked.Conn)
+	err = importPhysicalSchemaData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +169,15 @@ func importPhysicalSchema(d *schema.ResourceData, meta interface{}) ([]*schema.R
 	return []*schema.ResourceData{d}, nil
 }
 
-func importPhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
+func importPhysicalSchemaData(d internal.Data, c internal.Conn) error {
 
-	slice, err := c.FetchSlice("SELECT SCHEMA_NAME FROM EXA_SCHEMAS WHERE UPPER(SCHEMA_NAME) = UPPER(?) AND SCHEMA_IS_VIRTUAL = false", []interface{}{
-		d.Id(),
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	slice, err := c.FetchSlice("SELECT SCHEMA_NAME FROM EXA_SCHEMAS WHERE SCHEMA_NAME = ? AND SCHEMA_IS_VIRTUAL = false", []interface{}{
+		quote.Canonical(name, quoted),
 	}, "SYS")
 	if err != nil {
 		return err
@@ -113,25 +186,41 @@ func importPhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
 	if len(slice) == 0 {
 		return fmt.Errorf("Schema %s not found", d.Id())
 	}
-	d.SetId(strings.ToUpper(d.Id()))
+	err = d.Set("name", slice[0][0].(string))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+	d.SetId(resource.NewGlobalID(name, quoted))
 	return nil
 }
 
 func readPhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	return readPhysicalSchemaData(d, locked.Conn)
 }
 
-func readPhysicalSchemaData(d internal.Data, c *exasol.Conn) diag.Diagnostics {
+func readPhysicalSchemaData(d internal.Data, c internal.Conn) diag.Diagnostics {
 	name, err := argument.Name(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	res, err := c.FetchSlice("SELECT SCHEMA_NAME FROM EXA_SCHEMAS WHERE UPPER(SCHEMA_NAME) = UPPER(?) AND SCHEMA_IS_VIRTUAL = FALSE ", []interface{}{
-		name,
+	quoted := argument.QuotedIdentifier(d)
+	// Sizes and limit are only exposed by the object size views
+	res, err := c.FetchSlice(`SELECT S.SCHEMA_OWNER, S.SCHEMA_COMMENT, O.RAW_OBJECT_SIZE_LIMIT, O.RAW_OBJECT_SIZE, O.MEM_OBJECT_SIZE
+FROM EXA_SCHEMAS S
+LEFT JOIN EXA_ALL_OBJECT_SIZES O ON O.OBJECT_NAME = S.SCHEMA_NAME AND O.OBJECT_TYPE = 'SCHEMA'
+WHERE S.SCHEMA_NAME = ? AND S.SCHEMA_IS_VIRTUAL = FALSE`, []interface{}{
+		quote.Canonical(name, quoted),
 	}, "SYS")
 	if err != nil {
 		return diag.FromErr(err)
@@ -141,33 +230,86 @@ func readPhysicalSchemaData(d internal.Data, c *exasol.Conn) diag.Diagnostics {
 		return diag.Errorf("Schema %s not found", name)
 	}
 
-	d.SetId(strings.ToUpper(name))
+	owner, _ := res[0][0].(string)
+	err = d.Set("owner", owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	comment, _ := res[0][1].(string)
+	err = d.Set("comment", comment)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Missing limit is NULL
+	limit, _ := res[0][2].(float64)
+	err = d.Set("raw_size_limit", int(limit))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rawSize, _ := res[0][3].(float64)
+	err = d.Set("raw_object_size", int(rawSize))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	memSize, _ := res[0][4].(float64)
+	err = d.Set("mem_object_size", int(memSize))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
 	return nil
 }
 
-func updatePhysicalSchema(d *schema.ResourceData, meta interface{}) error {
+func updatePhysicalSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	err := updatePhysicalSchemaData(d, locked.Conn)
-	if err != nil {
-		return err
-	}
-	return locked.Conn.Commit()
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updatePhysicalSchemaData(d, conn))
+	})
 }
 
-func updatePhysicalSchemaData(d internal.Data, c *exasol.Conn) error {
+func updatePhysicalSchemaData(d internal.Data, c internal.Conn) error {
 
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
-		err := db.Rename(c, "SCHEMA", old.(string), new.(string), "")
+		err := db.RenameGlobal(c, "SCHEMA", old.(string), new.(string), argument.QuotedIdentifier(d))
 		if err != nil {
 			return err
 		}
 
 		d.Set("name", new)
+		d.SetId(resource.NewGlobalID(new.(string), argument.QuotedIdentifier(d)))
+	}
+
+	name := d.Get("name").(string)
+	quoted := argument.QuotedIdentifier(d)
+
+	if d.HasChange("owner") {
+		// A removed owner keeps the current one, as there is no
+		// default owner to reset to
+		owner, _ := argument.GetOkAsString(d, "owner")
+		if owner != "" {
+			err := changeSchemaOwner(c, name, owner, quoted)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("comment") {
+		comment, _ := d.Get("comment").(string)
+		err := db.Comment(c, "SCHEMA", name, comment, "", quoted)
+		if err != nil {
+			return err
+		}
 	}
 
-	_ = d.Get("name").(string)
+	if d.HasChange("raw_size_limit") {
+		limit, _ := d.Get("raw_size_limit").(int)
+		err := setRawSizeLimit(c, name, limit, quoted)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
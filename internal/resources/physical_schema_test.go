@@ -11,7 +11,7 @@ import (
 func TestCreatePhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
@@ -33,7 +33,7 @@ func TestCreatePhysicalSchema(t *testing.T) {
 func TestDeletePhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
@@ -59,7 +59,7 @@ func TestDeletePhysicalSchema(t *testing.T) {
 func TestImportPhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
@@ -93,7 +93,7 @@ func TestImportPhysicalSchema(t *testing.T) {
 func TestReadPhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
@@ -125,7 +125,7 @@ func TestReadPhysicalSchema(t *testing.T) {
 func TestRenamePhysicalSchema(t *testing.T) {
 	t.Parallel()
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
@@ -158,3 +158,82 @@ func TestRenamePhysicalSchema(t *testing.T) {
 		t.Fatalf("Expected name to be %s: %s", newName, name)
 	}
 }
+
+func TestUpdatePhysicalSchemaAttributes(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+
+	create := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":    name,
+			"comment": "Initial",
+		},
+	}
+
+	deletePhysicalSchemaData(create, locked.Conn)
+
+	err := createPhysicalSchemaData(create, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	update := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":           name,
+			"comment":        "Initial",
+			"raw_size_limit": 0,
+		},
+		NewValues: map[string]interface{}{
+			"name":           name,
+			"comment":        "Changed",
+			"raw_size_limit": 1024 * 1024 * 1024,
+		},
+	}
+
+	err = updatePhysicalSchemaData(update, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"name": name,
+		},
+	}
+
+	diags := readPhysicalSchemaData(read, locked.Conn)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	comment := read.Get("comment").(string)
+	if comment != "Changed" {
+		t.Fatalf("Expected comment Changed: %s", comment)
+	}
+	limit := read.Get("raw_size_limit").(int)
+	if limit != 1024*1024*1024 {
+		t.Fatalf("Expected raw_size_limit %d: %d", 1024*1024*1024, limit)
+	}
+	owner := read.Get("owner").(string)
+	if owner == "" {
+		t.Fatal("Expected owner to be set")
+	}
+}
+
+func TestChangeSchemaOwner(t *testing.T) {
+	t.Parallel()
+
+	c := &internal.TestConn{}
+	err := changeSchemaOwner(c, "Sales", "analyst", true)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := `ALTER SCHEMA "Sales" CHANGE OWNER "ANALYST"`
+	if len(c.Statements) != 1 || c.Statements[0] != expected {
+		t.Fatalf("Expected %s: %v", expected, c.Statements)
+	}
+}
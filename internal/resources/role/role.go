@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -19,67 +20,78 @@ func Resource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of Role",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Role",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"consumer_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Consumer Group to assign the Role to. Folded to upper case independent of quoted_identifier",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
 		},
-		Create:        create,
+		CreateContext: create,
 		UpdateContext: update,
-		Delete:        delete,
+		DeleteContext: delete,
 		Importer: &schema.ResourceImporter{
 			StateContext: imp,
 		},
 		ReadContext: read,
+		Timeouts:    resource.Timeouts(),
 	}
 }
 
-func create(d *schema.ResourceData, meta interface{}) error {
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	err := createData(d, locked.Conn)
-	if err != nil {
-		return err
-	}
-	return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassRole, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
 }
 
-func createData(d internal.Data, c *exasol.Conn) error {
+func createData(d internal.Data, c internal.Conn) error {
 
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	stmt := fmt.Sprintf("CREATE ROLE %s", name)
+	quoted := argument.QuotedIdentifier(d)
+	stmt := fmt.Sprintf("CREATE ROLE %s", quote.Name(name, quoted))
 	_, err = c.Execute(stmt)
 	if err != nil {
 		return err
 	}
-	d.SetId(strings.ToUpper(name))
+
+	group, _ := argument.GetOkAsString(d, "consumer_group")
+	if group != "" {
+		err = db.SetConsumerGroup(c, "ROLE", name, group, quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
 	return err
 }
 
-func delete(d *schema.ResourceData, meta interface{}) error {
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	err := deleteData(d, locked.Conn)
-	if err != nil {
-		return err
-	}
-	return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassRole, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
 }
 
-func deleteData(d internal.Data, c *exasol.Conn) error {
+func deleteData(d internal.Data, c internal.Conn) error {
 
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	stmt := fmt.Sprintf("DROP ROLE %s", name)
+	stmt := fmt.Sprintf("DROP ROLE %s", quote.Name(name, argument.QuotedIdentifier(d)))
 	_, err = c.Execute(stmt)
 	if err != nil {
 		return err
@@ -91,9 +103,12 @@ func deleteData(d internal.Data, c *exasol.Conn) error {
 
 func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importData(d, locked.Conn)
+	err = importData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -104,58 +119,93 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(d internal.Data, c *exasol.Conn) error {
-	name := d.Id()
-	if name == "" {
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
 		return errors.New("import expects id to be set")
 	}
-	name = strings.ToUpper(name)
-	err := d.Set("name", name)
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("name", quote.Canonical(name, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
 	if err != nil {
 		return err
 	}
+	d.SetId(resource.NewGlobalID(name, quoted))
 
 	_, err = readData(d, c)
-	return err
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Role %s not found", name)
+	}
+	return nil
 }
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	diags, _ := readData(d, locked.Conn)
 	return diags
 }
 
-func readData(d internal.Data, c *exasol.Conn) (diag.Diagnostics, error) {
+func readData(d internal.Data, c internal.Conn) (diag.Diagnostics, error) {
 	name, err := argument.Name(d)
 	if err != nil {
 		return diag.FromErr(err), err
 	}
-	_, err = c.FetchSlice("SELECT ROLE_NAME FROM EXA_ALL_ROLES WHERE ROLE_NAME = ?", []interface{}{
-		name,
+	res, err := c.FetchSlice("SELECT ROLE_CONSUMER_GROUP FROM EXA_DBA_ROLES WHERE ROLE_NAME = ?", []interface{}{
+		quote.Canonical(name, argument.QuotedIdentifier(d)),
 	}, "SYS")
-	return diag.FromErr(err), err
+	if err != nil {
+		return diag.FromErr(err), err
+	}
+	if len(res) == 0 {
+		// Role was dropped outside of Terraform
+		d.SetId("")
+		return nil, nil
+	}
+	group, _ := res[0][0].(string)
+	err = d.Set("consumer_group", group)
+	if err != nil {
+		return diag.FromErr(err), err
+	}
+	return nil, nil
 }
 
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
-	diags := updateData(d, locked.Conn)
-	if diags.HasError() {
-		return diags
-	}
-	err := locked.Conn.Commit()
-	return append(diags, diag.FromErr(err)...)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassRole, func(conn internal.Conn) diag.Diagnostics {
+		return updateData(d, conn)
+	})
 }
 
-func updateData(d internal.Data, c *exasol.Conn) diag.Diagnostics {
+func updateData(d internal.Data, c internal.Conn) diag.Diagnostics {
+
+	quoted := argument.QuotedIdentifier(d)
 
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
 
-		err := db.RenameGlobal(c, "ROLE", old.(string), new.(string))
+		err := db.RenameGlobal(c, "ROLE", old.(string), new.(string), quoted)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(resource.NewGlobalID(new.(string), quoted))
+	}
+
+	if d.HasChange("consumer_group") {
+		group, _ := d.Get("consumer_group").(string)
+		err := db.SetConsumerGroup(c, "ROLE", d.Get("name").(string), group, quoted)
 		if err != nil {
 			return diag.FromErr(err)
 		}
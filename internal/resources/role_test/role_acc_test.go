@@ -60,7 +60,7 @@ func TestAccExasolRole_import(t *testing.T) {
 
 	dbName := fmt.Sprintf("%s_%s", t.Name(), roleSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 	tryDeleteRole := func() {
 		stmt := fmt.Sprintf(`DROP ROLE %s`, dbName)
@@ -116,7 +116,10 @@ func testExistsNotByName(p *schema.Provider, actualName string) resource.TestChe
 	return func(state *terraform.State) error {
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		exists, err := exists(locked.Conn, actualName)
@@ -147,7 +150,10 @@ func testExist(p *schema.Provider, id string) resource.TestCheckFunc {
 		}
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		exists, err := exists(locked.Conn, actualName)
@@ -1,4 +1,4 @@
-package resourceprovider
+package rolegrant
 
 import (
 	"flag"
@@ -7,10 +7,12 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
 
 var (
-	exaClient *exaprovider.Client
+	exaClient  *exaprovider.Client
+	nameSuffix = acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 )
 
 func TestMain(m *testing.M) {
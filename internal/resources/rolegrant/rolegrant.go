@@ -0,0 +1,193 @@
+package rolegrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resource for granting a Role to a User or another Role
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Role to grant",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role to grant the Role to",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"with_admin_option": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Allows the grantee to grant the Role to others",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	role := d.Get("role").(string)
+	grantee := d.Get("grantee").(string)
+	quoted := argument.QuotedIdentifier(d)
+
+	stmt := fmt.Sprintf("GRANT %s TO %s", quote.Name(role, quoted), quote.Name(grantee, quoted))
+	admin, _ := d.Get("with_admin_option").(bool)
+	if admin {
+		stmt += " WITH ADMIN OPTION"
+	}
+	_, err := c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGrantID(grantee, quoted, resource.NewGlobalID(role, quoted)))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	quoted := argument.QuotedIdentifier(d)
+
+	stmt := fmt.Sprintf("REVOKE %s FROM %s", quote.Name(d.Get("role").(string), quoted), quote.Name(d.Get("grantee").(string), quoted))
+	_, err := c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	grantee, granteeQuoted, granted, err := resource.SplitGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+	role, roleQuoted, err := resource.SplitGlobalID(granted)
+	if err != nil {
+		return err
+	}
+
+	// Canonical names stay the same when quoted
+	err = d.Set("grantee", quote.Canonical(grantee, granteeQuoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("role", quote.Canonical(role, roleQuoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", granteeQuoted || roleQuoted)
+	if err != nil {
+		return err
+	}
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Role %s not granted to %s", role, grantee)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	role := d.Get("role").(string)
+	grantee := d.Get("grantee").(string)
+	quoted := argument.QuotedIdentifier(d)
+
+	res, err := c.FetchSlice("SELECT ADMIN_OPTION FROM EXA_DBA_ROLE_PRIVS WHERE GRANTEE = ? AND GRANTED_ROLE = ?", []interface{}{
+		quote.Canonical(grantee, quoted),
+		quote.Canonical(role, quoted),
+	}, "SYS")
+	if err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		// Role was revoked outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	admin, _ := res[0][0].(bool)
+	err = d.Set("with_admin_option", admin)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGrantID(grantee, quoted, resource.NewGlobalID(role, quoted)))
+	return nil
+}
@@ -0,0 +1,90 @@
+package rolegrant
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+const readStmt = "SELECT ADMIN_OPTION FROM EXA_DBA_ROLE_PRIVS WHERE GRANTEE = ? AND GRANTED_ROLE = ?"
+
+func TestGrantAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	reader := fmt.Sprintf("%s_READER_%s", t.Name(), nameSuffix)
+	admin := fmt.Sprintf("%s_ADMIN_%s", t.Name(), nameSuffix)
+	defer internal.MustCreateRoles(t, locked.Conn, reader, admin)()
+
+	internal.MustGrantAndRevoke(t, locked.Conn, &internal.TestData{
+		Values: map[string]interface{}{
+			"role":    reader,
+			"grantee": admin,
+		},
+	}, internal.GrantFuncs{
+		Create: createData,
+		Read:   readData,
+		Delete: deleteData,
+	})
+}
+
+func TestImportID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		id      string
+		grantee string
+		role    string
+		quoted  bool
+	}{
+		{"analyst:reader", "ANALYST", "READER", false},
+		{`"Analyst":"Reader"`, "Analyst", "Reader", true},
+		{`"a:b":"Reader"`, "a:b", "Reader", true},
+		{`ANALYST:"Reader"`, "ANALYST", "Reader", true},
+	}
+
+	for _, c := range cases {
+		conn := &internal.TestConn{
+			Rows: map[string][][]interface{}{
+				readStmt: {{true}},
+			},
+		}
+		d := &internal.TestData{
+			Values: map[string]interface{}{},
+		}
+		d.SetId(c.id)
+		err := importData(d, conn)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", c.id, err)
+		}
+		if d.Get("grantee") != c.grantee || d.Get("role") != c.role || d.Get("quoted_identifier") != c.quoted {
+			t.Errorf("Unexpected import of %s: %v", c.id, d.Values)
+		}
+		if d.Get("with_admin_option") != true {
+			t.Errorf("Expected admin option for %s", c.id)
+		}
+	}
+
+	for _, id := range []string{"analyst", "analyst:", `"analyst:reader`} {
+		d := &internal.TestData{
+			Values: map[string]interface{}{},
+		}
+		d.SetId(id)
+		err := importData(d, &internal.TestConn{})
+		if err == nil {
+			t.Errorf("Expected error for %s", id)
+		}
+	}
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	d.SetId("analyst:reader")
+	err := importData(d, &internal.TestConn{})
+	if err == nil {
+		t.Fatal("Expected error for Role not granted")
+	}
+}
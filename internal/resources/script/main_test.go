@@ -0,0 +1,47 @@
+package script
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+const (
+	schemaName = "resources_script_TestMain"
+)
+
+var (
+	exaClient  *exaprovider.Client
+	nameSuffix = acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(testRun(m))
+}
+
+func testRun(m *testing.M) int {
+	exaClient = internal.MustCreateTestClient()
+
+	func() {
+		locked := internal.MustLock(exaClient)
+		defer locked.Unlock()
+		locked.Conn.Execute(fmt.Sprintf("CREATE SCHEMA %s", schemaName))
+		db.MustCommit(locked.Conn)
+	}()
+
+	defer func() {
+		locked := internal.MustLock(exaClient)
+		defer locked.Unlock()
+		locked.Conn.Execute(fmt.Sprintf("DROP SCHEMA %s CASCADE", schemaName))
+		locked.Conn.Commit()
+	}()
+
+	return m.Run()
+}
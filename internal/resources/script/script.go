@@ -0,0 +1,365 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	Parameter = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of Parameter",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SQL type of Parameter. Parameters of scripting Scripts only allow ARRAY",
+			},
+		},
+	}
+)
+
+// Resource for Exasol Script
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Script",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"schema": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Schema to create Script in",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"language": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Language of Script like PYTHON3, JAVA or LUA",
+				StateFunc:        argument.UpperCase,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Kind of Script. One of SCALAR, SET, ADAPTER or SCRIPTING",
+				ValidateFunc: validation.StringInSlice(statements.ScriptKinds, false),
+			},
+			"input": {
+				Type:        schema.TypeList,
+				Elem:        Parameter,
+				Optional:    true,
+				Description: "Parameters passed to the Script",
+			},
+			"emits": {
+				Type:          schema.TypeList,
+				Elem:          Parameter,
+				Optional:      true,
+				Description:   "Columns emitted by a SCALAR or SET Script",
+				ConflictsWith: []string{"returns"},
+			},
+			"returns": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "SQL type returned by a SCALAR or SET Script. TABLE or ROWCOUNT for a SCRIPTING Script",
+				ConflictsWith: []string{"emits"},
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Code of the Script",
+				DiffSuppressFunc: argument.SuppressBodyDiff,
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn, ra))
+	})...)
+}
+
+func parameters(d internal.Data, key string) []statements.ScriptParameter {
+	listiface, ok := d.Get(key).([]interface{})
+	if !ok {
+		return nil
+	}
+	params := make([]statements.ScriptParameter, 0, len(listiface))
+	for _, paramiface := range listiface {
+		p := paramiface.(map[string]interface{})
+		t, _ := p["type"].(string)
+		params = append(params, statements.ScriptParameter{
+			Name: p["name"].(string),
+			Type: t,
+		})
+	}
+	return params
+}
+
+func createData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
+
+	quoted := argument.QuotedIdentifier(d)
+	returns, _ := d.Get("returns").(string)
+	cs := statements.CreateScript{
+		Schema:   args.Schema,
+		Name:     args.Name,
+		Language: d.Get("language").(string),
+		Kind:     d.Get("kind").(string),
+		Input:    parameters(d, "input"),
+		Emits:    parameters(d, "emits"),
+		Returns:  returns,
+		Body:     d.Get("body").(string),
+		Quoted:   quoted,
+	}
+
+	err := cs.Execute(c)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn, ra))
+	})...)
+}
+
+func deleteData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
+
+	ds := statements.DropScript{
+		Schema:  args.Schema,
+		Name:    args.Name,
+		Adapter: d.Get("kind").(string) == statements.ScriptKindAdapter,
+		Quoted:  argument.QuotedIdentifier(d),
+	}
+	err := ds.Execute(c)
+	if err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	id := d.Id()
+
+	m, quoted, err := resource.SplitQuotedID(id, d.Get("schema").(string))
+	if err != nil {
+		return err
+	}
+
+	if len(strings.TrimSpace(m.Schema)) == 0 {
+		return errors.New("missing schema in import")
+	}
+
+	err = d.Set("name", m.ObjectName)
+	if err != nil {
+		return err
+	}
+	err = d.Set("schema", m.Schema)
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+
+	diags := readData(d, c, argument.RequiredArguments{
+		Schema: m.Schema,
+		Name:   m.ObjectName,
+	})
+	if diags.HasError() {
+		return fmt.Errorf("Script %s not importable: %s", id, diags[0].Summary)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, readData(d, locked.Conn, ra)...)
+}
+
+func readData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
+
+	quoted := argument.QuotedIdentifier(d)
+	res, err := c.FetchSlice("SELECT SCRIPT_LANGUAGE, SCRIPT_TYPE, SCRIPT_INPUT_TYPE, SCRIPT_TEXT FROM EXA_ALL_SCRIPTS WHERE SCRIPT_SCHEMA = ? AND SCRIPT_NAME = ?", []interface{}{
+		quote.Canonical(args.Schema, quoted),
+		quote.Canonical(args.Name, quoted),
+	}, "SYS")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(res) == 0 {
+		return diag.Errorf("Script %s.%s not found", args.Schema, args.Name)
+	}
+
+	row := res[0]
+	language, _ := row[0].(string)
+	err = d.Set("language", language)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("kind", scriptKind(row[1], row[2]))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	text, _ := row[3].(string)
+	st, err := statements.ParseScriptText(text)
+	if err != nil {
+		return diag.Errorf("Script %s.%s has unexpected text: %s", args.Schema, args.Name, err)
+	}
+	err = d.Set("input", parameterList(keepTypeSpelling(d, "input", st.Input)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("emits", parameterList(keepTypeSpelling(d, "emits", st.Emits)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("returns", st.Returns)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("body", st.Body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
+	return nil
+}
+
+// scriptKind maps SCRIPT_TYPE and SCRIPT_INPUT_TYPE of EXA_ALL_SCRIPTS
+// to the kind of a Script
+func scriptKind(scriptType, inputType interface{}) string {
+	switch scriptType {
+	case "UDF":
+		kind, _ := inputType.(string)
+		return kind
+	case "PROCEDURE":
+		return statements.ScriptKindScripting
+	}
+	kind, _ := scriptType.(string)
+	return kind
+}
+
+// keepTypeSpelling keeps the configured spelling of types like ARRAY,
+// which Exasol only reports in upper case
+func keepTypeSpelling(d internal.Data, key string, params []statements.ScriptParameter) []statements.ScriptParameter {
+	configured := parameters(d, key)
+	for i := range params {
+		if i < len(configured) && strings.EqualFold(configured[i].Type, params[i].Type) {
+			params[i].Type = configured[i].Type
+		}
+	}
+	return params
+}
+
+// parameterList converts params to the form of input and emits
+func parameterList(params []statements.ScriptParameter) []interface{} {
+	l := make([]interface{}, 0, len(params))
+	for _, p := range params {
+		l = append(l, map[string]interface{}{
+			"name": p.Name,
+			"type": p.Type,
+		})
+	}
+	return l
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	ra, diags := argument.ExtractRequiredArguments(d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn, ra))
+	})...)
+}
+
+func updateData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
+
+	// CREATE OR REPLACE keeps the Script in place for dependants
+	replaceNecessary := d.HasChange("language") || d.HasChange("input") || d.HasChange("emits") || d.HasChange("returns") || d.HasChange("body")
+	if replaceNecessary {
+		return createData(d, c, args)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package script
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+)
+
+func TestCreateAndRead(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	args := argument.RequiredArguments{
+		Schema: schemaName,
+		Name:   name,
+	}
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	create := &internal.TestData{
+		Values: map[string]interface{}{
+			"language": "LUA",
+			"kind":     "SCALAR",
+			"input": []interface{}{
+				map[string]interface{}{
+					"name": "x",
+					"type": "DOUBLE",
+				},
+			},
+			"returns": "DOUBLE",
+			"body":    "function run(ctx)\n  return ctx.x * 2\nend",
+		},
+	}
+	err := createData(create, locked.Conn, args)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	diags := readData(read, locked.Conn, args)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	if read.Get("kind") != "SCALAR" {
+		t.Fatalf("Unexpected kind: %s", read.Get("kind"))
+	}
+	body := strings.TrimSpace(read.Get("body").(string))
+	if body != "function run(ctx)\n  return ctx.x * 2\nend" {
+		t.Fatalf("Unexpected body: %s", body)
+	}
+	if read.Get("returns") != "DOUBLE" {
+		t.Fatalf("Unexpected returns: %s", read.Get("returns"))
+	}
+	input := read.Get("input").([]interface{})
+	if len(input) != 1 || input[0].(map[string]interface{})["name"] != "x" {
+		t.Fatalf("Unexpected input: %v", input)
+	}
+}
+
+func TestReadScripting(t *testing.T) {
+	t.Parallel()
+
+	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	args := argument.RequiredArguments{
+		Schema: schemaName,
+		Name:   name,
+	}
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	create := &internal.TestData{
+		Values: map[string]interface{}{
+			"language": "LUA",
+			"kind":     "SCRIPTING",
+			"input": []interface{}{
+				map[string]interface{}{
+					"name": "tables",
+					"type": "ARRAY",
+				},
+			},
+			"returns": "ROWCOUNT",
+			"body":    "return 0",
+		},
+	}
+	err := createData(create, locked.Conn, args)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	diags := readData(read, locked.Conn, args)
+	if diags.HasError() {
+		t.Fatal("Unexpected error:", diags)
+	}
+
+	if read.Get("kind") != "SCRIPTING" {
+		t.Fatalf("Unexpected kind: %s", read.Get("kind"))
+	}
+	if read.Get("returns") != "ROWCOUNT" {
+		t.Fatalf("Unexpected returns: %s", read.Get("returns"))
+	}
+	input := read.Get("input").([]interface{})
+	if len(input) != 1 || input[0].(map[string]interface{})["type"] != "ARRAY" {
+		t.Fatalf("Unexpected input: %v", input)
+	}
+}
+
+func TestScriptKind(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		scriptType interface{}
+		inputType  interface{}
+		kind       string
+	}{
+		{"UDF", "SCALAR", "SCALAR"},
+		{"UDF", "SET", "SET"},
+		{"ADAPTER", nil, "ADAPTER"},
+		{"PROCEDURE", nil, "SCRIPTING"},
+	}
+	for _, c := range cases {
+		kind := scriptKind(c.scriptType, c.inputType)
+		if kind != c.kind {
+			t.Errorf("Expected kind %s for %v: %s", c.kind, c.scriptType, kind)
+		}
+	}
+}
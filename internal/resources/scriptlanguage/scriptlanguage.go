@@ -0,0 +1,301 @@
+package scriptlanguage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/internal/resources/systemparameter"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const parameter = "SCRIPT_LANGUAGES"
+
+var (
+	aliasReg = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	urlReg   = regexp.MustCompile(`^\S+$`)
+)
+
+// Resource for one alias within SCRIPT_LANGUAGES. Aliases of
+// others are kept as they are.
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"alias": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Alias used in CREATE SCRIPT like PYTHON3_CUSTOM",
+				ValidateFunc: validation.StringMatch(aliasReg, "alias has to be a regular identifier"),
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Location of the language container like localzmq+protobuf:///bfsdefault/default/...",
+				ValidateFunc: validation.StringMatch(urlReg, "url must not contain whitespace"),
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+// entry is one ALIAS=url of SCRIPT_LANGUAGES
+type entry struct {
+	alias string
+	url   string
+}
+
+// parse splits value of SCRIPT_LANGUAGES into its entries
+func parse(value string) ([]entry, error) {
+	fields := strings.Fields(value)
+	entries := make([]entry, 0, len(fields))
+	for _, f := range fields {
+		i := strings.Index(f, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid %s entry %s", parameter, f)
+		}
+		entries = append(entries, entry{
+			alias: f[:i],
+			url:   f[i+1:],
+		})
+	}
+	return entries, nil
+}
+
+func format(entries []entry) string {
+	fields := make([]string, 0, len(entries))
+	for _, e := range entries {
+		fields = append(fields, e.alias+"="+e.url)
+	}
+	return strings.Join(fields, " ")
+}
+
+// merge sets url of alias in value. Other entries keep their order.
+func merge(value, alias, url string) (string, error) {
+	entries, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	for i, e := range entries {
+		if strings.EqualFold(e.alias, alias) {
+			entries[i].url = url
+			return format(entries), nil
+		}
+	}
+	return format(append(entries, entry{alias: alias, url: url})), nil
+}
+
+// remove drops alias from value
+func remove(value, alias string) (string, error) {
+	entries, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if !strings.EqualFold(e.alias, alias) {
+			kept = append(kept, e)
+		}
+	}
+	return format(kept), nil
+}
+
+// lookup returns url of alias in value
+func lookup(value, alias string) (string, bool, error) {
+	entries, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	for _, e := range entries {
+		if strings.EqualFold(e.alias, alias) {
+			return e.url, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func alias(d internal.Data) (string, error) {
+	a := d.Get("alias").(string)
+	if !aliasReg.MatchString(a) {
+		return "", fmt.Errorf("invalid alias %s", a)
+	}
+	return strings.ToUpper(a), nil
+}
+
+// write merges the alias of d into SCRIPT_LANGUAGES. Running within
+// globallock makes a collision with other writers re-read the value.
+func write(d internal.Data, c internal.Conn) error {
+	a, err := alias(d)
+	if err != nil {
+		return err
+	}
+
+	current, err := systemparameter.Get(c, parameter)
+	if err != nil {
+		return err
+	}
+	value, err := merge(current, a, d.Get("url").(string))
+	if err != nil {
+		return err
+	}
+	if value == current {
+		return nil
+	}
+	return systemparameter.Set(c, parameter, value)
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	err := write(d, c)
+	if err != nil {
+		return err
+	}
+
+	a, _ := alias(d)
+	d.SetId(a)
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn))
+	})
+}
+
+func updateData(d internal.Data, c internal.Conn) error {
+	if !d.HasChange("url") {
+		return nil
+	}
+	return write(d, c)
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	a, err := alias(d)
+	if err != nil {
+		return err
+	}
+
+	current, err := systemparameter.Get(c, parameter)
+	if err != nil {
+		return err
+	}
+	value, err := remove(current, a)
+	if err != nil {
+		return err
+	}
+	if value != current {
+		err = systemparameter.Set(c, parameter, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	err := d.Set("alias", strings.ToUpper(d.Id()))
+	if err != nil {
+		return err
+	}
+	a, err := alias(d)
+	if err != nil {
+		return err
+	}
+	d.SetId(a)
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("alias %s not found in %s", a, parameter)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	a, err := alias(d)
+	if err != nil {
+		return err
+	}
+
+	current, err := systemparameter.Get(c, parameter)
+	if err != nil {
+		return err
+	}
+	url, ok, err := lookup(current, a)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Alias was removed outside of Terraform
+		d.SetId("")
+		return nil
+	}
+	return d.Set("url", url)
+}
@@ -0,0 +1,95 @@
+package scriptlanguage
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+const (
+	getStmt  = "SELECT SYSTEM_VALUE FROM EXA_PARAMETERS WHERE PARAMETER_NAME = ?"
+	builtins = "PYTHON=builtin_python R=builtin_r JAVA=builtin_java PYTHON3=builtin_python3"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	value, err := merge(builtins, "MY_PY", "localzmq+protobuf:///bfsdefault/default/my_py?lang=python#buckets/bfsdefault/default/my_py/exaudf/exaudfclient_py3")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := builtins + " MY_PY=localzmq+protobuf:///bfsdefault/default/my_py?lang=python#buckets/bfsdefault/default/my_py/exaudf/exaudfclient_py3"
+	if value != expected {
+		t.Fatalf("Expected %s: %s", expected, value)
+	}
+
+	value, err = merge(builtins, "r", "builtin_r4")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected = "PYTHON=builtin_python R=builtin_r4 JAVA=builtin_java PYTHON3=builtin_python3"
+	if value != expected {
+		t.Fatalf("Expected %s: %s", expected, value)
+	}
+
+	_, err = merge("PYTHON", "R", "builtin_r")
+	if err == nil {
+		t.Fatal("Expected error for invalid entry")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	value, err := remove(builtins, "java")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := "PYTHON=builtin_python R=builtin_r PYTHON3=builtin_python3"
+	if value != expected {
+		t.Fatalf("Expected %s: %s", expected, value)
+	}
+}
+
+func TestCreateKeepsOtherAliases(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			getStmt: {{builtins}},
+		},
+	}
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"alias": "my_r",
+			"url":   "localzmq+protobuf:///bfsdefault/default/my_r?lang=r#buckets/bfsdefault/default/my_r/exaudf/exaudfclient",
+		},
+	}
+
+	err := createData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() != "MY_R" {
+		t.Fatalf("Unexpected id: %s", d.Id())
+	}
+	expected := "ALTER SYSTEM SET SCRIPT_LANGUAGES = '" + builtins + " MY_R=localzmq+protobuf:///bfsdefault/default/my_r?lang=r#buckets/bfsdefault/default/my_r/exaudf/exaudfclient'"
+	last := conn.Statements[len(conn.Statements)-1]
+	if last != expected {
+		t.Fatalf("Expected %s: %s", expected, last)
+	}
+
+	read := &internal.TestData{
+		Values: map[string]interface{}{
+			"alias": "JAVA_OLD",
+		},
+	}
+	read.SetId("JAVA_OLD")
+	err = readData(read, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if read.Id() != "" {
+		t.Fatal("Expected missing alias to reset id")
+	}
+}
@@ -0,0 +1,303 @@
+package systemparameter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// kind decides how a value is written in ALTER SYSTEM
+type kind int
+
+const (
+	kindLiteral kind = iota
+	kindNumber
+	kindIdentifier
+)
+
+var (
+	// parameters settable via ALTER SYSTEM
+	parameters = map[string]kind{
+		"CONSTRAINT_STATE_DEFAULT":      kindLiteral,
+		"DEFAULT_CONSUMER_GROUP":        kindIdentifier,
+		"DEFAULT_LIKE_ESCAPE_CHARACTER": kindLiteral,
+		"HASHTYPE_FORMAT":               kindLiteral,
+		"IDLE_TIMEOUT":                  kindNumber,
+		"NLS_DATE_FORMAT":               kindLiteral,
+		"NLS_DATE_LANGUAGE":             kindLiteral,
+		"NLS_FIRST_DAY_OF_WEEK":         kindNumber,
+		"NLS_NUMERIC_CHARACTERS":        kindLiteral,
+		"NLS_TIMESTAMP_FORMAT":          kindLiteral,
+		"PASSWORD_EXPIRY_POLICY":        kindLiteral,
+		"PASSWORD_SECURITY_POLICY":      kindLiteral,
+		"PROFILE":                       kindLiteral,
+		"QUERY_CACHE":                   kindLiteral,
+		"QUERY_TIMEOUT":                 kindNumber,
+		"SCRIPT_LANGUAGES":              kindLiteral,
+		"SESSION_TEMP_DB_RAM_LIMIT":     kindLiteral,
+		"SNAPSHOT_MODE":                 kindLiteral,
+		"SQL_PREPROCESSOR_SCRIPT":       kindIdentifier,
+		"TEMP_DB_RAM_LIMIT":             kindLiteral,
+		"TIMESTAMP_ARITHMETIC_BEHAVIOR": kindLiteral,
+		"TIME_ZONE":                     kindLiteral,
+		"TIME_ZONE_BEHAVIOR":            kindLiteral,
+		"USER_TEMP_DB_RAM_LIMIT":        kindLiteral,
+	}
+)
+
+func names() []string {
+	n := make([]string, 0, len(parameters))
+	for k := range parameters {
+		n = append(n, k)
+	}
+	sort.Strings(n)
+	return n
+}
+
+// Resource for setting a database-wide system parameter
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the system parameter like QUERY_TIMEOUT",
+				ValidateFunc: validation.StringInSlice(names(), true),
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Value of the system parameter",
+				DiffSuppressFunc: suppressValueDiff,
+			},
+			"original_value": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Value before Terraform took over. Restored on destroy",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		UpdateContext: update,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func suppressValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	if parameters[strings.ToUpper(d.Get("name").(string))] == kindIdentifier {
+		return strings.EqualFold(old, new)
+	}
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+func parameterName(d internal.Data) (string, error) {
+	name := strings.ToUpper(d.Get("name").(string))
+	if _, ok := parameters[name]; !ok {
+		return "", fmt.Errorf("unknown system parameter %s", name)
+	}
+	return name, nil
+}
+
+// Get fetches the system-wide value of parameter. Unset values
+// are returned as empty string.
+func Get(c internal.Conn, parameter string) (string, error) {
+	res, err := c.FetchSlice("SELECT SYSTEM_VALUE FROM EXA_PARAMETERS WHERE PARAMETER_NAME = ?", []interface{}{
+		parameter,
+	}, "SYS")
+	if err != nil {
+		return "", err
+	}
+	if len(res) == 0 {
+		return "", fmt.Errorf("system parameter %s not found", parameter)
+	}
+	value, _ := res[0][0].(string)
+	return value, nil
+}
+
+// Set changes the system-wide value of parameter. An empty value
+// resets parameters that are not literals to NULL.
+func Set(c internal.Conn, parameter, value string) error {
+	k, ok := parameters[parameter]
+	if !ok {
+		return fmt.Errorf("unknown system parameter %s", parameter)
+	}
+
+	v := quote.Literal(value)
+	switch {
+	case k != kindLiteral && value == "":
+		v = "NULL"
+	case k == kindNumber:
+		v = strings.TrimSpace(value)
+		if strings.Trim(v, "0123456789") != "" {
+			return fmt.Errorf("system parameter %s expects a number: %s", parameter, value)
+		}
+	case k == kindIdentifier:
+		parts, quoted, err := quote.SplitQualified(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		for i, p := range parts {
+			parts[i] = quote.Name(p, quoted)
+		}
+		v = strings.Join(parts, ".")
+	}
+
+	stmt := fmt.Sprintf("ALTER SYSTEM SET %s = %s", parameter, v)
+	_, err := c.Execute(stmt)
+	return err
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	name, err := parameterName(d)
+	if err != nil {
+		return err
+	}
+
+	original, err := Get(c, name)
+	if err != nil {
+		return err
+	}
+	err = d.Set("original_value", original)
+	if err != nil {
+		return err
+	}
+
+	err = Set(c, name, d.Get("value").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(name)
+	return nil
+}
+
+func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn))
+	})
+}
+
+func updateData(d internal.Data, c internal.Conn) error {
+	if !d.HasChange("value") {
+		return nil
+	}
+	name, err := parameterName(d)
+	if err != nil {
+		return err
+	}
+	return Set(c, name, d.Get("value").(string))
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassSystem, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	name, err := parameterName(d)
+	if err != nil {
+		return err
+	}
+
+	original, _ := d.Get("original_value").(string)
+	err = Set(c, name, original)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	err := d.Set("name", strings.ToUpper(d.Id()))
+	if err != nil {
+		return err
+	}
+	name, err := parameterName(d)
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	// Without knowledge of an earlier value, destroy keeps the
+	// current one
+	return d.Set("original_value", d.Get("value"))
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	name, err := parameterName(d)
+	if err != nil {
+		return err
+	}
+
+	value, err := Get(c, name)
+	if err != nil {
+		return err
+	}
+	return d.Set("value", value)
+}
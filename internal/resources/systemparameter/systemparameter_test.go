@@ -0,0 +1,101 @@
+package systemparameter
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+const getStmt = "SELECT SYSTEM_VALUE FROM EXA_PARAMETERS WHERE PARAMETER_NAME = ?"
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		parameter string
+		value     string
+		stmt      string
+	}{
+		{"QUERY_TIMEOUT", "120", "ALTER SYSTEM SET QUERY_TIMEOUT = 120"},
+		{"NLS_DATE_FORMAT", "DD.MM.YYYY", "ALTER SYSTEM SET NLS_DATE_FORMAT = 'DD.MM.YYYY'"},
+		{"PASSWORD_SECURITY_POLICY", "MIN_LENGTH=8", "ALTER SYSTEM SET PASSWORD_SECURITY_POLICY = 'MIN_LENGTH=8'"},
+		{"SQL_PREPROCESSOR_SCRIPT", "tools.preprocess", `ALTER SYSTEM SET SQL_PREPROCESSOR_SCRIPT = "TOOLS"."PREPROCESS"`},
+		{"SQL_PREPROCESSOR_SCRIPT", "", "ALTER SYSTEM SET SQL_PREPROCESSOR_SCRIPT = NULL"},
+	}
+
+	for _, test := range tests {
+		conn := &internal.TestConn{}
+		err := Set(conn, test.parameter, test.value)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", test.parameter, err)
+		}
+		if len(conn.Statements) != 1 || conn.Statements[0] != test.stmt {
+			t.Errorf("Expected %s: %v", test.stmt, conn.Statements)
+		}
+	}
+
+	err := Set(&internal.TestConn{}, "QUERY_TIMEOUT", "1; DROP USER SYS")
+	if err == nil {
+		t.Fatal("Expected error for non numeric value")
+	}
+	err = Set(&internal.TestConn{}, "UNKNOWN", "1")
+	if err == nil {
+		t.Fatal("Expected error for unknown parameter")
+	}
+}
+
+func TestRestoreOriginal(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			getStmt: {{"0"}},
+		},
+	}
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":  "query_timeout",
+			"value": "300",
+		},
+	}
+
+	err := createData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() != "QUERY_TIMEOUT" {
+		t.Fatalf("Unexpected id: %s", d.Id())
+	}
+	if d.Get("original_value") != "0" {
+		t.Fatalf("Expected original value 0: %v", d.Get("original_value"))
+	}
+
+	err = deleteData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	last := conn.Statements[len(conn.Statements)-1]
+	if last != "ALTER SYSTEM SET QUERY_TIMEOUT = 0" {
+		t.Fatalf("Expected original value to be restored: %s", last)
+	}
+}
+
+func TestReadParameter(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name": "NLS_DATE_FORMAT",
+		},
+	}
+	err := readData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Get("value") == "" {
+		t.Fatal("Expected NLS_DATE_FORMAT to be set")
+	}
+}
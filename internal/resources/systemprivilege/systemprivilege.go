@@ -0,0 +1,216 @@
+package systemprivilege
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	privilegeReg = regexp.MustCompile(`^[A-Za-z]+(\s+[A-Za-z]+)*$`)
+)
+
+// Resource for granting a system privilege to a User or Role
+func Resource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"grantee": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "User or Role to grant the privilege to",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"privilege": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "System privilege like CREATE SESSION or CREATE TABLE",
+				ValidateFunc:     validation.StringMatch(privilegeReg, "privilege has to consist of words only"),
+				DiffSuppressFunc: suppressPrivilegeDiff,
+			},
+			"with_admin_option": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Allows the grantee to grant the privilege to others",
+			},
+		},
+		CreateContext: create,
+		ReadContext:   read,
+		DeleteContext: delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: imp,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func suppressPrivilegeDiff(k, old, new string, d *schema.ResourceData) bool {
+	return Normalize(old) == Normalize(new)
+}
+
+// Normalize folds privilege to the form stored in EXA_DBA_SYS_PRIVS
+func Normalize(privilege string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(privilege), " "))
+}
+
+func privilege(d internal.Data) (string, error) {
+	p := d.Get("privilege").(string)
+	if !privilegeReg.MatchString(p) {
+		return "", fmt.Errorf("invalid privilege %s", p)
+	}
+	return Normalize(p), nil
+}
+
+func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
+	})
+}
+
+func createData(d internal.Data, c internal.Conn) error {
+	grantee := d.Get("grantee").(string)
+	p, err := privilege(d)
+	if err != nil {
+		return err
+	}
+
+	quoted := argument.QuotedIdentifier(d)
+	stmt := fmt.Sprintf("GRANT %s TO %s", p, quote.Name(grantee, quoted))
+	admin, _ := d.Get("with_admin_option").(bool)
+	if admin {
+		stmt += " WITH ADMIN OPTION"
+	}
+	_, err = c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(resource.NewGrantID(grantee, quoted, p))
+	return nil
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassPrivilege, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
+	grantee := d.Get("grantee").(string)
+	p, err := privilege(d)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("REVOKE %s FROM %s", p, quote.Name(grantee, argument.QuotedIdentifier(d)))
+	_, err = c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importData(d internal.Data, c internal.Conn) error {
+	if d.Id() == "" {
+		return errors.New("import expects id to be set")
+	}
+	grantee, quoted, p, err := resource.SplitGrantID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("grantee", quote.Canonical(grantee, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+	err = d.Set("privilege", Normalize(p))
+	if err != nil {
+		return err
+	}
+	d.SetId(resource.NewGrantID(grantee, quoted, Normalize(p)))
+
+	err = readData(d, c)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("privilege %s not granted to %s", p, grantee)
+	}
+	return nil
+}
+
+func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return diag.FromErr(readData(d, locked.Conn))
+}
+
+func readData(d internal.Data, c internal.Conn) error {
+	grantee := d.Get("grantee").(string)
+	p, err := privilege(d)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.FetchSlice("SELECT ADMIN_OPTION FROM EXA_DBA_SYS_PRIVS WHERE GRANTEE = ? AND PRIVILEGE = ?", []interface{}{
+		quote.Canonical(grantee, argument.QuotedIdentifier(d)),
+		p,
+	}, "SYS")
+	if err != nil {
+		return err
+	}
+
+	if len(res) == 0 {
+		// Privilege was revoked outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	admin, _ := res[0][0].(bool)
+	return d.Set("with_admin_option", admin)
+}
@@ -0,0 +1,71 @@
+package systemprivilege
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+var grantFuncs = internal.GrantFuncs{
+	Create: createData,
+	Read:   readData,
+	Delete: deleteData,
+}
+
+func TestGrantAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	role := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	defer internal.MustCreateRoles(t, locked.Conn, role)()
+
+	internal.MustGrantAndRevoke(t, locked.Conn, &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":   role,
+			"privilege": "create  session",
+		},
+	}, grantFuncs)
+}
+
+func TestReadAdminOption(t *testing.T) {
+	t.Parallel()
+
+	locked := internal.MustLock(exaClient)
+	defer locked.Unlock()
+
+	role := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
+	defer internal.MustCreateRoles(t, locked.Conn, role)()
+
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"grantee":           role,
+			"privilege":         "CREATE TABLE",
+			"with_admin_option": true,
+		},
+	}
+	err := createData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// Admin option is taken from the database instead of state
+	d.Set("with_admin_option", false)
+	err = readData(d, locked.Conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Get("with_admin_option") != true {
+		t.Fatal("Expected admin option to be read")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	if p := Normalize(" create\tany  table "); p != "CREATE ANY TABLE" {
+		t.Fatalf("Unexpected privilege: %s", p)
+	}
+}
@@ -11,11 +11,12 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
-	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -26,16 +27,19 @@ func Resource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of Table",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Table",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
 			"schema": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Schema to create Table in",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Schema to create Table in",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
 			"composite": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -80,8 +84,9 @@ func Resource() *schema.Resource {
 		UpdateContext: update,
 		DeleteContext: delete,
 		Importer: &schema.ResourceImporter{
-			State: imp,
+			StateContext: imp,
 		},
+		Timeouts: resource.Timeouts(),
 	}
 }
 
@@ -91,20 +96,16 @@ func isReplaceFalse(ctx context.Context, d *schema.ResourceDiff, meta interface{
 
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	diags = append(diags, diag.FromErr(createData(d, locked.Conn, ra, false))...)
-	if diags.HasError() {
-		return diags
-	}
-	return append(diags, diag.FromErr(locked.Conn.Commit())...)
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn, ra, false))
+	})...)
 }
 
-func createData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments, replace bool) error {
+func createData(d internal.Data, c internal.Conn, args argument.RequiredArguments, replace bool) error {
 
 	comp := d.Get("composite")
 	like := d.Get("like")
@@ -127,15 +128,16 @@ func createData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments
 	return postCreate(d, c, args.Schema, args.Name)
 }
 
-func postCreate(d internal.Data, c *exasol.Conn, schema, name string) error {
+func postCreate(d internal.Data, c internal.Conn, schema, name string) error {
 
-	state, err := fetchMaterializedColumns(c, schema, name)
+	quoted := argument.QuotedIdentifier(d)
+	state, err := fetchMaterializedColumns(c, quote.Canonical(schema, quoted), quote.Canonical(name, quoted))
 	if err != nil {
 		return err
 	}
 	setMaterializedColumnHash(state, d)
 
-	tr, err := computed.ReadTable(c, schema, name)
+	tr, err := computed.ReadTable(c, schema, name, quoted)
 	if err != nil {
 		return err
 	}
@@ -160,12 +162,12 @@ func postCreate(d internal.Data, c *exasol.Conn, schema, name string) error {
 		return err
 	}
 
-	d.SetId(resource.NewID(schema, name))
+	d.SetId(resource.NewQuotedID(schema, name, quoted))
 	return nil
 }
 
 // createDataMutate contains the mutating part of creating a Table
-func createDataMutate(d internal.Data, c *exasol.Conn, schema, name string, comp, like, subquery interface{}, replace bool) error {
+func createDataMutate(d internal.Data, c internal.Conn, schema, name string, comp, like, subquery interface{}, replace bool) error {
 
 	initWords := "CREATE TABLE"
 	if replace {
@@ -175,9 +177,12 @@ func createDataMutate(d internal.Data, c *exasol.Conn, schema, name string, comp
 	commentSuffix := ""
 	comment, ok := d.Get("comment").(string)
 	if comment != "" && ok {
-		commentSuffix = fmt.Sprintf(" COMMENT IS '%s'", comment)
+		commentSuffix = fmt.Sprintf(" COMMENT IS %s", quote.Literal(comment))
 	}
 
+	quoted := argument.QuotedIdentifier(d)
+	name = quote.Qualified(schema, name, quoted)
+	schema = quote.Canonical(schema, quoted)
 	var err error
 	if !reflect.ValueOf(comp).IsZero() {
 		cleaned := strings.Trim(comp.(string), ",\n ")
@@ -201,23 +206,19 @@ func createDataMutate(d internal.Data, c *exasol.Conn, schema, name string, comp
 
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	err := deleteData(d, locked.Conn, ra)
-	if err != nil {
-		return append(diags, diag.FromErr(err)...)
-	}
-	return append(diags, diag.FromErr(locked.Conn.Commit())...)
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn, ra))
+	})...)
 }
 
-func deleteData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) error {
+func deleteData(d internal.Data, c internal.Conn, args argument.RequiredArguments) error {
 
-	stmt := fmt.Sprintf("DROP TABLE %s", args.Name)
-	_, err := c.Execute(stmt, nil, args.Schema)
+	stmt := fmt.Sprintf("DROP TABLE %s", quote.Qualified(args.Schema, args.Name, argument.QuotedIdentifier(d)))
+	_, err := c.Execute(stmt)
 	if err != nil {
 		return err
 	}
@@ -226,11 +227,14 @@ func deleteData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments
 	return nil
 }
 
-func imp(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importData(d, locked.Conn)
+	err = importData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -241,10 +245,10 @@ func imp(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, erro
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(d internal.Data, c *exasol.Conn) error {
+func importData(d internal.Data, c internal.Conn) error {
 	id := d.Id()
 
-	m, err := resource.GetMetaFromQNDefault(id, d.Get("schema").(string))
+	m, quoted, err := resource.SplitQuotedID(id, d.Get("schema").(string))
 	if err != nil {
 		return err
 	}
@@ -261,8 +265,12 @@ func importData(d internal.Data, c *exasol.Conn) error {
 	if err != nil {
 		return err
 	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
 
-	tr, err := computed.ReadTable(c, m.Schema, m.ObjectName)
+	tr, err := computed.ReadTable(c, m.Schema, m.ObjectName, quoted)
 	if err != nil {
 		return err
 	}
@@ -297,7 +305,10 @@ func importData(d internal.Data, c *exasol.Conn) error {
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
@@ -306,9 +317,10 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	return append(diags, readData(d, locked.Conn, ra)...)
 }
 
-func readData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func readData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
-	tr, err := computed.ReadTable(c, args.Schema, args.Name)
+	quoted := argument.QuotedIdentifier(d)
+	tr, err := computed.ReadTable(c, args.Schema, args.Name, quoted)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -358,31 +370,27 @@ func readData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments)
 		return diag.FromErr(err)
 	}
 
-	d.SetId(resource.NewID(args.Schema, args.Name))
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
 	return nil
 }
 
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	diags = append(diags, updateData(d, locked.Conn, ra)...)
-	if diags.HasError() {
-		return diags
-	}
-	return append(diags, diag.FromErr(locked.Conn.Commit())...)
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return updateData(d, conn, ra)
+	})...)
 }
 
-func updateData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func updateData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
 
-		err := db.Rename(c, "TABLE", old.(string), new.(string), args.Schema)
+		err := db.Rename(c, "TABLE", old.(string), new.(string), args.Schema, argument.QuotedIdentifier(d))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -398,7 +406,7 @@ func updateData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments
 			return diag.FromErr(err)
 		}
 	} else if d.HasChange("comment") {
-		err := db.Comment(c, "TABLE", d.Get("name").(string), d.Get("comment").(string), args.Schema)
+		err := db.Comment(c, "TABLE", d.Get("name").(string), d.Get("comment").(string), args.Schema, argument.QuotedIdentifier(d))
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -418,11 +426,11 @@ func countEmpty(elems ...interface{}) int {
 	return i
 }
 
-func fetchMaterializedColumns(c *exasol.Conn, schema, table string) ([][]interface{}, error) {
+func fetchMaterializedColumns(c internal.Conn, schema, table string) ([][]interface{}, error) {
 	stmt := `SELECT COLUMN_OBJECT_TYPE, COLUMN_NAME, COLUMN_TYPE,
 COLUMN_TYPE_ID, COLUMN_MAXSIZE, COLUMN_NUM_PREC, COLUMN_NUM_SCALE,
 COLUMN_IS_VIRTUAL, COLUMN_IS_NULLABLE, COLUMN_IS_DISTRIBUTION_KEY, COLUMN_PARTITION_KEY_ORDINAL_POSITION, COLUMN_DEFAULT,
-COLUMN_IDENTITY FROM EXA_ALL_COLUMNS WHERE UPPER(COLUMN_SCHEMA) = UPPER(?) AND UPPER(COLUMN_TABLE) = UPPER(?) ORDER BY COLUMN_ORDINAL_POSITION`
+COLUMN_IDENTITY FROM EXA_ALL_COLUMNS WHERE COLUMN_SCHEMA = ? AND COLUMN_TABLE = ? ORDER BY COLUMN_ORDINAL_POSITION`
 
 	res, err := c.FetchSlice(stmt, []interface{}{
 		schema,
@@ -4,45 +4,70 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
 	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var (
+	// authentications are the exclusive authentication methods
+	authentications = []string{"ldap", "kerberos", "openid_subject", "password"}
+)
+
 // Resource for Exasol User
 func Resource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of User",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of User",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
 			"password": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				Description:  "",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				Description:  "Authentication using a password",
+				ExactlyOneOf: authentications,
+			},
+			"replace_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Change the password with REPLACING the previous one. Needed when the provider logs in as the User itself instead of as admin",
 			},
 			"kerberos": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "Authentication using Kerberos Principals. The defined principal looks like <user>@<realm>",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				ExactlyOneOf: authentications,
 			},
 			"ldap": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Authentication using LDAP",
-				ExactlyOneOf: []string{"ldap", "kerberos", "password"},
+				Description:  "Authentication using LDAP. Distinguished name of the User",
+				ExactlyOneOf: authentications,
+			},
+			"openid_subject": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Authentication using OpenID. Subject of the User at the identity provider",
+				ExactlyOneOf: authentications,
+			},
+			"consumer_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Consumer Group to assign the User to. Folded to upper case independent of quoted_identifier",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
 		},
 		CreateContext: create,
@@ -52,82 +77,84 @@ func Resource() *schema.Resource {
 			StateContext: imp,
 		},
 		ReadContext: read,
+		Timeouts:    resource.Timeouts(),
 	}
 }
 
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := createData(d, locked.Conn)
-		if err != nil {
-			return err
-		}
-		return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassUser, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createData(d, conn))
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
 }
 
-func createData(d internal.Data, c *exasol.Conn) error {
+func createData(d internal.Data, c internal.Conn) error {
 
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	var stmt string
-
-	password := d.Get("password")
-	kerberos := d.Get("kerberos")
-	ldap := d.Get("ldap")
-
-	if password != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED BY "%s"`, name, password)
-	} else if kerberos != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED BY KERBEROS PRINCIPAL '%s'`, name, password)
-	} else if ldap != "" {
-		stmt = fmt.Sprintf(`CREATE USER %s IDENTIFIED AT LDAP AS '%s'`, name, password)
-	} else {
-		return errors.New("no identification found")
+	quoted := argument.QuotedIdentifier(d)
+	identification, err := identificationClause(d)
+	if err != nil {
+		return err
 	}
 
+	stmt := fmt.Sprintf("CREATE USER %s %s", quote.Name(name, quoted), identification)
 	_, err = c.Execute(stmt)
 	if err != nil {
 		return err
 	}
-	d.SetId(strings.ToUpper(name))
-	return err
-}
 
-func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	err := globallock.RunAndRetryRollbacks(func() error {
-		c := meta.(*exaprovider.Client)
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := deleteData(d, locked.Conn)
+	group, _ := argument.GetOkAsString(d, "consumer_group")
+	if group != "" {
+		err = db.SetConsumerGroup(c, "USER", name, group, quoted)
 		if err != nil {
 			return err
 		}
-		return locked.Conn.Commit()
-	})
-	if err != nil {
-		return diag.FromErr(err)
 	}
-	return nil
+
+	d.SetId(resource.NewGlobalID(name, quoted))
+	return err
 }
 
-func deleteData(d internal.Data, c *exasol.Conn) error {
+// identificationClause returns the IDENTIFIED clause of the
+// authentication method set in d
+func identificationClause(d internal.Data) (string, error) {
+	password, _ := argument.GetOkAsString(d, "password")
+	kerberos, _ := argument.GetOkAsString(d, "kerberos")
+	ldap, _ := argument.GetOkAsString(d, "ldap")
+	openID, _ := argument.GetOkAsString(d, "openid_subject")
+
+	switch {
+	case password != "":
+		return fmt.Sprintf("IDENTIFIED BY %s", quote.Identifier(password)), nil
+	case kerberos != "":
+		return fmt.Sprintf("IDENTIFIED BY KERBEROS PRINCIPAL %s", quote.Literal(kerberos)), nil
+	case ldap != "":
+		return fmt.Sprintf("IDENTIFIED AT LDAP AS %s", quote.Literal(ldap)), nil
+	case openID != "":
+		return fmt.Sprintf("IDENTIFIED BY OPENID SUBJECT %s", quote.Literal(openID)), nil
+	}
+	return "", errors.New("no identification found")
+}
+
+func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassUser, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteData(d, conn))
+	})
+}
+
+func deleteData(d internal.Data, c internal.Conn) error {
 
 	name, err := argument.Name(d)
 	if err != nil {
 		return err
 	}
 
-	stmt := fmt.Sprintf("DROP USER %s", name)
+	stmt := fmt.Sprintf("DROP USER %s", quote.Name(name, argument.QuotedIdentifier(d)))
 	_, err = c.Execute(stmt)
 	if err != nil {
 		return err
@@ -139,9 +166,12 @@ func deleteData(d internal.Data, c *exasol.Conn) error {
 
 func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importData(d, locked.Conn)
+	err = importData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -149,14 +179,22 @@ func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*sche
 }
 
 func importData(d internal.Data, c internal.Conn) error {
-	name := d.Id()
-	if name == "" {
+	if d.Id() == "" {
 		return errors.New("import expects id to be set")
 	}
-	err := d.Set("name", name)
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+	err = d.Set("name", quote.Canonical(name, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
 	if err != nil {
 		return err
 	}
+	d.SetId(resource.NewGlobalID(name, quoted))
 
 	err = readData(d, c)
 	if errors.Is(err, db.ErrorNamedObjectNotFound) {
@@ -167,9 +205,12 @@ func importData(d internal.Data, c internal.Conn) error {
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
-	err := readData(d, locked.Conn)
+	err = readData(d, locked.Conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,8 +223,8 @@ func readData(d internal.Data, c internal.Conn) error {
 		return err
 	}
 
-	res, err := c.FetchSlice("SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL FROM EXA_DBA_USERS WHERE UPPER(USER_NAME) = UPPER(?)", []interface{}{
-		name,
+	res, err := c.FetchSlice("SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL, USER_CONSUMER_GROUP, OPENID_SUBJECT FROM EXA_DBA_USERS WHERE USER_NAME = ?", []interface{}{
+		quote.Canonical(name, argument.QuotedIdentifier(d)),
 	}, "SYS")
 	if err != nil {
 		return err
@@ -193,64 +234,77 @@ func readData(d internal.Data, c internal.Conn) error {
 		return db.ErrorNamedObjectNotFound
 	}
 
-	ldapIf := res[0][0]
-	kerberosIf := res[0][1]
-	if ldapIf != nil {
-		err = d.Set("ldap", ldapIf.(string))
-		if err != nil {
-			return err
-		}
-		err = d.Set("kerberos", nil)
-		if err != nil {
-			return err
-		}
-		err = d.Set("password", nil)
-		if err != nil {
-			return err
-		}
-	} else if kerberosIf != nil {
-		err = d.Set("ldap", nil)
-		if err != nil {
-			return err
-		}
-		err = d.Set("kerberos", kerberosIf.(string))
-		if err != nil {
-			return err
+	group, _ := res[0][2].(string)
+	err = d.Set("consumer_group", group)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case res[0][0] != nil:
+		return setAuthentication(d, "ldap", res[0][0].(string))
+	case res[0][1] != nil:
+		return setAuthentication(d, "kerberos", res[0][1].(string))
+	case res[0][3] != nil:
+		return setAuthentication(d, "openid_subject", res[0][3].(string))
+	}
+	// Passwords cannot be read back, so the known one is kept
+	return setAuthentication(d, "password", "")
+}
+
+// setAuthentication sets method and clears all other authentication
+// methods. An empty value keeps the current value of method.
+func setAuthentication(d internal.Data, method, value string) error {
+	for _, a := range authentications {
+		var err error
+		switch {
+		case a != method:
+			err = d.Set(a, nil)
+		case value != "":
+			err = d.Set(a, value)
 		}
-		err = d.Set("password", nil)
 		if err != nil {
 			return err
 		}
-	} else {
-		//TODO: implement
 	}
-
 	return nil
 }
 
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	err := globallock.RunAndRetryRollbacks(func() error {
-		locked := c.Lock()
-		defer locked.Unlock()
-		err := updateData(d, locked.Conn)
-		if err != nil {
-			return err
-		}
-		return locked.Conn.Commit()
+	return globallock.MutateClass(ctx, c, exaprovider.ObjectClassUser, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateData(d, conn))
 	})
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
 }
 
-func updateData(d internal.Data, c *exasol.Conn) error {
+func updateData(d internal.Data, c internal.Conn) error {
+
+	quoted := argument.QuotedIdentifier(d)
 
 	if d.HasChange("name") {
 		old, new := d.GetChange("name")
 
-		err := db.RenameGlobal(c, "USER", old.(string), new.(string))
+		err := db.RenameGlobal(c, "USER", old.(string), new.(string), quoted)
+		if err != nil {
+			return err
+		}
+		d.SetId(resource.NewGlobalID(new.(string), quoted))
+	}
+
+	authChanged := false
+	for _, a := range authentications {
+		authChanged = authChanged || d.HasChange(a)
+	}
+	if authChanged {
+		err := changeAuthentication(d, c, quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("consumer_group") {
+		group, _ := d.Get("consumer_group").(string)
+		err := db.SetConsumerGroup(c, "USER", d.Get("name").(string), group, quoted)
 		if err != nil {
 			return err
 		}
@@ -258,3 +312,27 @@ func updateData(d internal.Data, c *exasol.Conn) error {
 
 	return readData(d, c)
 }
+
+// changeAuthentication switches the User to the authentication
+// method set in d
+func changeAuthentication(d internal.Data, c internal.Conn, quoted bool) error {
+	identification, err := identificationClause(d)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("ALTER USER %s %s", quote.Name(d.Get("name").(string), quoted), identification)
+	replace, _ := d.Get("replace_password").(bool)
+	password, _ := argument.GetOkAsString(d, "password")
+	if replace && password != "" {
+		old, _ := d.GetChange("password")
+		oldPassword, _ := old.(string)
+		if oldPassword == "" {
+			return errors.New("replace_password needs the previous password")
+		}
+		stmt += fmt.Sprintf(" REPLACING %s", quote.Identifier(oldPassword))
+	}
+
+	_, err = c.Execute(stmt)
+	return err
+}
@@ -0,0 +1,187 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+const readStmt = "SELECT DISTINGUISHED_NAME, KERBEROS_PRINCIPAL, USER_CONSUMER_GROUP, OPENID_SUBJECT FROM EXA_DBA_USERS WHERE USER_NAME = ?"
+
+func TestCreateQuotesAuthentication(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values map[string]interface{}
+		stmt   string
+	}{
+		{
+			map[string]interface{}{"name": "u", "password": `p"w`},
+			`CREATE USER "U" IDENTIFIED BY "p""w"`,
+		},
+		{
+			map[string]interface{}{"name": "u", "kerberos": "u'x@REALM"},
+			`CREATE USER "U" IDENTIFIED BY KERBEROS PRINCIPAL 'u''x@REALM'`,
+		},
+		{
+			map[string]interface{}{"name": "u", "ldap": "cn=u,dc=example,dc=com"},
+			`CREATE USER "U" IDENTIFIED AT LDAP AS 'cn=u,dc=example,dc=com'`,
+		},
+		{
+			map[string]interface{}{"name": "u", "openid_subject": "analyst@example.com"},
+			`CREATE USER "U" IDENTIFIED BY OPENID SUBJECT 'analyst@example.com'`,
+		},
+	}
+
+	for _, test := range tests {
+		conn := &internal.TestConn{}
+		d := &internal.TestData{
+			Values: test.values,
+		}
+		err := createData(d, conn)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if conn.Statements[0] != test.stmt {
+			t.Errorf("Expected %s: %s", test.stmt, conn.Statements[0])
+		}
+	}
+}
+
+func TestUpdateSwitchesAuthentication(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			readStmt: {{"cn=u,dc=example,dc=com", nil, nil, nil}},
+		},
+	}
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":           "u",
+			"password":       "secret",
+			"consumer_group": "",
+		},
+		NewValues: map[string]interface{}{
+			"name":           "u",
+			"ldap":           "cn=u,dc=example,dc=com",
+			"consumer_group": "",
+		},
+	}
+
+	err := updateData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := `ALTER USER "U" IDENTIFIED AT LDAP AS 'cn=u,dc=example,dc=com'`
+	if conn.Statements[0] != expected {
+		t.Fatalf("Expected %s: %v", expected, conn.Statements)
+	}
+	if d.Values["ldap"] != "cn=u,dc=example,dc=com" || d.Values["password"] != nil {
+		t.Fatalf("Expected LDAP to be read back: %v", d.Values)
+	}
+}
+
+func TestUpdateReplacesPassword(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			readStmt: {{nil, nil, nil, nil}},
+		},
+	}
+	d := &internal.TestData{
+		Values: map[string]interface{}{
+			"name":             "u",
+			"password":         "old",
+			"replace_password": true,
+			"consumer_group":   "",
+		},
+		NewValues: map[string]interface{}{
+			"name":             "u",
+			"password":         "new",
+			"replace_password": true,
+			"consumer_group":   "",
+		},
+	}
+
+	err := updateData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := `ALTER USER "U" IDENTIFIED BY "new" REPLACING "old"`
+	if conn.Statements[0] != expected {
+		t.Fatalf("Expected %s: %v", expected, conn.Statements)
+	}
+}
+
+func TestReadClassifiesAuthentication(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		row      []interface{}
+		expected map[string]interface{}
+	}{
+		{
+			[]interface{}{"cn=u", nil, nil, nil},
+			map[string]interface{}{"ldap": "cn=u", "kerberos": nil, "openid_subject": nil, "password": nil},
+		},
+		{
+			[]interface{}{nil, "u@REALM", nil, nil},
+			map[string]interface{}{"ldap": nil, "kerberos": "u@REALM", "openid_subject": nil, "password": nil},
+		},
+		{
+			[]interface{}{nil, nil, nil, "analyst@example.com"},
+			map[string]interface{}{"ldap": nil, "kerberos": nil, "openid_subject": "analyst@example.com", "password": nil},
+		},
+		{
+			[]interface{}{nil, nil, nil, nil},
+			map[string]interface{}{"ldap": nil, "kerberos": nil, "openid_subject": nil, "password": "known"},
+		},
+	}
+
+	for _, test := range tests {
+		conn := &internal.TestConn{
+			Rows: map[string][][]interface{}{
+				readStmt: {test.row},
+			},
+		}
+		d := &internal.TestData{
+			Values: map[string]interface{}{
+				"name":     "u",
+				"password": "known",
+			},
+		}
+		err := readData(d, conn)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		for k, v := range test.expected {
+			if d.Values[k] != v {
+				t.Errorf("Expected %s to be %v: %v", k, v, d.Values[k])
+			}
+		}
+	}
+}
+
+func TestImportOpenIDUser(t *testing.T) {
+	t.Parallel()
+
+	conn := &internal.TestConn{
+		Rows: map[string][][]interface{}{
+			readStmt: {{nil, nil, nil, "analyst@example.com"}},
+		},
+	}
+	d := &internal.TestData{
+		Values: map[string]interface{}{},
+	}
+	d.SetId("ANALYST")
+
+	err := importData(d, conn)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Values["openid_subject"] != "analyst@example.com" {
+		t.Fatalf("Expected OpenID subject to be imported: %v", d.Values)
+	}
+}
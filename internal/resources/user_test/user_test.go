@@ -63,7 +63,10 @@ func testExistsNotByName(p *schema.Provider, actualName string) resource.TestChe
 	return func(state *terraform.State) error {
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		exists, err := exists(locked.Conn, actualName)
@@ -94,7 +97,10 @@ func testExist(p *schema.Provider, id string) resource.TestCheckFunc {
 		}
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		exists, err := exists(locked.Conn, actualName)
@@ -8,11 +8,11 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
 	"github.com/abergmeier/terraform-provider-exasol/internal/statements"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/computed"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
-	"github.com/grantstreetgroup/go-exasol-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -39,17 +39,20 @@ func Resource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of View",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of View",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
 			"schema": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Schema to create View in",
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Schema to create View in",
+				ForceNew:         true,
+				DiffSuppressFunc: argument.SuppressCaseDiff,
 			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
 			"column": {
 				Type:        schema.TypeList,
 				Elem:        Column,
@@ -81,8 +84,9 @@ func Resource() *schema.Resource {
 		UpdateContext: update,
 		DeleteContext: delete,
 		Importer: &schema.ResourceImporter{
-			State: imp,
+			StateContext: imp,
 		},
+		Timeouts: resource.Timeouts(),
 	}
 }
 
@@ -115,17 +119,13 @@ func requiredCreateArguments(d *schema.ResourceData) (RequiredCreateArguments, d
 
 func create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ca, diags := requiredCreateArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	diags = append(diags, createData(d, locked.Conn, ca, false)...)
-	if diags.HasError() {
-		return diags
-	}
-	return diag.FromErr(locked.Conn.Commit())
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return createData(d, conn, ca, false)
+	})...)
 }
 
 func appendColumns(columns []statements.ViewColumn, d internal.Data) []statements.ViewColumn {
@@ -150,13 +150,14 @@ func appendColumns(columns []statements.ViewColumn, d internal.Data) []statement
 	return columns
 }
 
-func createData(d internal.Data, c *exasol.Conn, args RequiredCreateArguments, replace bool) diag.Diagnostics {
+func createData(d internal.Data, c internal.Conn, args RequiredCreateArguments, replace bool) diag.Diagnostics {
 
 	diags := diag.Diagnostics{}
 	comment, _ := argument.GetOkAsString(d, "comment")
 
 	var columns []statements.ViewColumn
 	columns = appendColumns(columns, d)
+	quoted := argument.QuotedIdentifier(d)
 
 	cv := statements.CreateView{
 		Schema:   args.Schema,
@@ -165,6 +166,7 @@ func createData(d internal.Data, c *exasol.Conn, args RequiredCreateArguments, r
 		Subquery: args.subquery,
 		Comment:  comment,
 		Replace:  replace,
+		Quoted:   quoted,
 	}
 
 	err := cv.Execute(c)
@@ -172,30 +174,27 @@ func createData(d internal.Data, c *exasol.Conn, args RequiredCreateArguments, r
 		return append(diags, diag.FromErr(err)...)
 	}
 
-	d.SetId(resource.NewID(args.Schema, args.Name))
+	d.SetId(resource.NewQuotedID(args.Schema, args.Name, quoted))
 	return diags
 }
 
 func delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	diags = append(diags, deleteData(d, locked.Conn, ra)...)
-	if diags.HasError() {
-		return diags
-	}
-	return append(diags, diag.FromErr(locked.Conn.Commit())...)
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return deleteData(d, conn, ra)
+	})...)
 }
 
-func deleteData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func deleteData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
 	dv := statements.DropView{
 		Schema: args.Schema,
 		Name:   args.Name,
+		Quoted: argument.QuotedIdentifier(d),
 	}
 	err := dv.Execute(c)
 	if err != nil {
@@ -205,11 +204,14 @@ func deleteData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments
 	return nil
 }
 
-func imp(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func imp(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer locked.Unlock()
-	err := importData(d, locked.Conn)
+	err = importData(d, locked.Conn)
 	if err != nil {
 		return nil, err
 	}
@@ -220,10 +222,10 @@ func imp(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, erro
 	return []*schema.ResourceData{d}, nil
 }
 
-func importData(d internal.Data, c *exasol.Conn) error {
+func importData(d internal.Data, c internal.Conn) error {
 	id := d.Id()
 
-	m, err := resource.GetMetaFromQNDefault(id, d.Get("schema").(string))
+	m, quoted, err := resource.SplitQuotedID(id, d.Get("schema").(string))
 	if err != nil {
 		return err
 	}
@@ -240,8 +242,12 @@ func importData(d internal.Data, c *exasol.Conn) error {
 	if err != nil {
 		return err
 	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
 
-	tv, err := computed.ReadView(c, m.Schema, m.ObjectName)
+	tv, err := computed.ReadView(c, m.Schema, m.ObjectName, quoted)
 	if err != nil {
 		return err
 	}
@@ -251,13 +257,16 @@ func importData(d internal.Data, c *exasol.Conn) error {
 		return err
 	}
 
-	d.SetId(resource.NewID(m.Schema, m.ObjectName))
+	d.SetId(resource.NewQuotedID(m.Schema, m.ObjectName, quoted))
 	return nil
 }
 
 func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
 	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
@@ -266,9 +275,9 @@ func read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Di
 	return readData(d, locked.Conn, ra)
 }
 
-func readData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func readData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
-	tr, err := computed.ReadView(c, args.Schema, args.Name)
+	tr, err := computed.ReadView(c, args.Schema, args.Name, argument.QuotedIdentifier(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -293,20 +302,16 @@ func readData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments)
 
 func update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*exaprovider.Client)
-	locked := c.Lock()
-	defer locked.Unlock()
 	ra, diags := argument.ExtractRequiredArguments(d)
 	if diags.HasError() {
 		return diags
 	}
-	diags = append(diags, updateData(d, locked.Conn, ra)...)
-	if diags.HasError() {
-		return diags
-	}
-	return append(diags, diag.FromErr(locked.Conn.Commit())...)
+	return append(diags, globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return updateData(d, conn, ra)
+	})...)
 }
 
-func updateData(d internal.Data, c *exasol.Conn, args argument.RequiredArguments) diag.Diagnostics {
+func updateData(d internal.Data, c internal.Conn, args argument.RequiredArguments) diag.Diagnostics {
 
 	var diags diag.Diagnostics
 	replaceNecessary := d.HasChange("column") || d.HasChange("comment") || d.HasChange("subquery")
@@ -14,7 +14,7 @@ func TestCreate(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 	locked.Conn.Execute(fmt.Sprintf("DROP VIEW %s", name), nil, schemaName)
 
@@ -46,7 +46,7 @@ func TestColumn(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 	locked.Conn.Execute(fmt.Sprintf("DROP VIEW %s", name), nil, schemaName)
 
@@ -85,7 +85,7 @@ func TestDelete(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	locked.Conn.Execute(fmt.Sprintf("CREATE OR REPLACE VIEW %s AS SELECT COLUMN_TYPE FROM SYS.EXA_ALL_COLUMNS", name), nil, schemaName)
@@ -114,7 +114,7 @@ func TestComment(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	locked.Conn.Execute(fmt.Sprintf("CREATE OR REPLACE VIEW %s AS SELECT COLUMN_TYPE FROM SYS.EXA_ALL_COLUMNS", name), nil, schemaName)
@@ -156,7 +156,7 @@ func TestImport(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 
 	locked.Conn.Execute(fmt.Sprintf("CREATE OR REPLACE VIEW %s AS SELECT COLUMN_NAME FROM SYS.EXA_ALL_COLUMNS", name), nil, schemaName)
@@ -189,7 +189,7 @@ func TestUpdate(t *testing.T) {
 
 	name := fmt.Sprintf("%s_%s", t.Name(), nameSuffix)
 
-	locked := exaClient.Lock()
+	locked := internal.MustLock(exaClient)
 	defer locked.Unlock()
 	locked.Conn.Execute(fmt.Sprintf("DROP VIEW %s", name), nil, schemaName)
 
@@ -0,0 +1,380 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/internal/exaprovider"
+	"github.com/abergmeier/terraform-provider-exasol/internal/globallock"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/db"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// VirtualSchema returns the schema.Resource for managing a virtual Schema
+func VirtualSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Name of Schema",
+				DiffSuppressFunc: argument.SuppressCaseDiff,
+			},
+			"quoted_identifier": argument.QuotedIdentifierSchema(),
+			"adapter_script": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Adapter Script as in CREATE VIRTUAL SCHEMA FOO USING <adapter_script>",
+				DiffSuppressFunc: suppressAdapterScriptDiff,
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Properties passed to the Adapter Script",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comment for the Schema",
+			},
+			"refresh_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value. Changing it refreshes the metadata of the Schema",
+			},
+		},
+		CreateContext: createVirtualSchema,
+		ReadContext:   readVirtualSchema,
+		UpdateContext: updateVirtualSchema,
+		DeleteContext: deleteVirtualSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: importVirtualSchema,
+		},
+		Timeouts: resource.Timeouts(),
+	}
+}
+
+func suppressAdapterScriptDiff(k, old, new string, d *schema.ResourceData) bool {
+	return sameAdapterScript(old, new, argument.QuotedIdentifier(d))
+}
+
+// sameAdapterScript checks whether old and new name the same Adapter
+// Script. An unqualified name matches the Script in any Schema since
+// Exasol resolves it against the current Schema.
+func sameAdapterScript(old, new string, quoted bool) bool {
+	oldSchema, oldName, err := splitAdapterScript(old, quoted)
+	if err != nil {
+		return false
+	}
+	newSchema, newName, err := splitAdapterScript(new, quoted)
+	if err != nil {
+		return false
+	}
+	if oldName != newName {
+		return false
+	}
+	return oldSchema == "" || newSchema == "" || oldSchema == newSchema
+}
+
+// splitAdapterScript returns the canonical Schema and name of the
+// Adapter Script. Delimited parts or quoted select quoted identifiers.
+func splitAdapterScript(script string, quoted bool) (schema, name string, err error) {
+	parts, delimited, err := quote.SplitQualified(script)
+	if err != nil {
+		return "", "", err
+	}
+	quoted = quoted || delimited
+	switch len(parts) {
+	case 1:
+		return "", quote.Canonical(parts[0], quoted), nil
+	case 2:
+		return quote.Canonical(parts[0], quoted), quote.Canonical(parts[1], quoted), nil
+	}
+	return "", "", fmt.Errorf("Adapter Script %s has too many parts", script)
+}
+
+// adapterScript returns the possibly qualified name of the Adapter Script
+// as identifier
+func adapterScript(script string, quoted bool) (string, error) {
+	schema, name, err := splitAdapterScript(script, quoted)
+	if err != nil {
+		return "", err
+	}
+	if schema == "" {
+		return quote.Identifier(name), nil
+	}
+	return quote.Identifier(schema) + "." + quote.Identifier(name), nil
+}
+
+// propertyAssignments formats properties as in KEY1 = 'value1' KEY2 = 'value2'
+func propertyAssignments(properties map[string]interface{}) string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	assignments := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v, _ := properties[k].(string)
+		assignments = append(assignments, fmt.Sprintf("%s = %s", quote.RegularIdentifier(k), quote.Literal(v)))
+	}
+	return strings.Join(assignments, " ")
+}
+
+func createVirtualSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(createVirtualSchemaData(d, conn))
+	})
+}
+
+func createVirtualSchemaData(d internal.Data, c internal.Conn) error {
+	name, err := argument.Name(d)
+	if err != nil {
+		return err
+	}
+
+	quoted := argument.QuotedIdentifier(d)
+	script, err := adapterScript(d.Get("adapter_script").(string), quoted)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("CREATE VIRTUAL SCHEMA %s USING %s", quote.Name(name, quoted), script)
+	properties, _ := d.Get("properties").(map[string]interface{})
+	if len(properties) > 0 {
+		stmt = fmt.Sprintf("%s WITH %s", stmt, propertyAssignments(properties))
+	}
+	_, err = c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	comment, _ := argument.GetOkAsString(d, "comment")
+	if comment != "" {
+		err = db.Comment(c, "SCHEMA", name, comment, "", quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
+	return nil
+}
+
+func deleteVirtualSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(deleteVirtualSchemaData(d, conn))
+	})
+}
+
+func deleteVirtualSchemaData(d internal.Data, c internal.Conn) error {
+	name := d.Get("name").(string)
+
+	stmt := fmt.Sprintf("DROP VIRTUAL SCHEMA %s CASCADE", quote.Name(name, argument.QuotedIdentifier(d)))
+	_, err := c.Execute(stmt)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func importVirtualSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer locked.Unlock()
+	err = importVirtualSchemaData(d, locked.Conn)
+	if err != nil {
+		return nil, err
+	}
+	err = locked.Conn.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func importVirtualSchemaData(d internal.Data, c internal.Conn) error {
+
+	name, quoted, err := resource.SplitGlobalID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("name", quote.Canonical(name, quoted))
+	if err != nil {
+		return err
+	}
+	err = d.Set("quoted_identifier", quoted)
+	if err != nil {
+		return err
+	}
+
+	id := d.Id()
+	diags := readVirtualSchemaData(d, c)
+	if diags.HasError() {
+		return fmt.Errorf("Virtual Schema %s not importable: %s", id, diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return fmt.Errorf("Virtual Schema %s not found", id)
+	}
+	return nil
+}
+
+func readVirtualSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	locked, err := c.LockContext(ctx)
+	if err != nil {
+		return exaprovider.ConnectDiagnostics(err)
+	}
+	defer locked.Unlock()
+	return readVirtualSchemaData(d, locked.Conn)
+}
+
+func readVirtualSchemaData(d internal.Data, c internal.Conn) diag.Diagnostics {
+	name, err := argument.Name(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quoted := argument.QuotedIdentifier(d)
+	canonical := quote.Canonical(name, quoted)
+	res, err := c.FetchSlice(`SELECT V.ADAPTER_SCRIPT_SCHEMA, V.ADAPTER_SCRIPT_NAME, S.SCHEMA_COMMENT
+FROM EXA_ALL_VIRTUAL_SCHEMAS V
+JOIN EXA_ALL_SCHEMAS S ON S.SCHEMA_NAME = V.SCHEMA_NAME
+WHERE V.SCHEMA_NAME = ?`, []interface{}{
+		canonical,
+	}, "SYS")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(res) == 0 {
+		// Virtual Schema was dropped outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	scriptSchema, _ := res[0][0].(string)
+	scriptName, _ := res[0][1].(string)
+	err = d.Set("adapter_script", quote.Qualified(scriptSchema, scriptName, true))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	comment, _ := res[0][2].(string)
+	err = d.Set("comment", comment)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	props, err := c.FetchSlice("SELECT PROPERTY_NAME, PROPERTY_VALUE FROM EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES WHERE SCHEMA_NAME = ?", []interface{}{
+		canonical,
+	}, "SYS")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the spelling of configured keys since Exasol folds them to upper case
+	configured, _ := d.Get("properties").(map[string]interface{})
+	properties := make(map[string]interface{}, len(props))
+	for _, row := range props {
+		key := row[0].(string)
+		for k := range configured {
+			if strings.EqualFold(k, key) {
+				key = k
+				break
+			}
+		}
+		value, _ := row[1].(string)
+		properties[key] = value
+	}
+	err = d.Set("properties", properties)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.NewGlobalID(name, quoted))
+	return nil
+}
+
+func updateVirtualSchema(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*exaprovider.Client)
+	return globallock.Mutate(ctx, c, func(conn internal.Conn) diag.Diagnostics {
+		return diag.FromErr(updateVirtualSchemaData(d, conn))
+	})
+}
+
+func updateVirtualSchemaData(d internal.Data, c internal.Conn) error {
+
+	quoted := argument.QuotedIdentifier(d)
+	if d.HasChange("name") {
+		old, new := d.GetChange("name")
+		err := db.RenameGlobal(c, "SCHEMA", old.(string), new.(string), quoted)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(resource.NewGlobalID(new.(string), quoted))
+	}
+
+	name := quote.Name(d.Get("name").(string), quoted)
+
+	if d.HasChange("properties") {
+		old, new := d.GetChange("properties")
+		oldProperties := old.(map[string]interface{})
+		newProperties := new.(map[string]interface{})
+		changed := map[string]interface{}{}
+		for k, v := range newProperties {
+			if oldProperties[k] != v {
+				changed[k] = v
+			}
+		}
+		// Properties set to an empty value are removed by Exasol
+		for k := range oldProperties {
+			if _, ok := newProperties[k]; !ok {
+				changed[k] = ""
+			}
+		}
+		if len(changed) > 0 {
+			stmt := fmt.Sprintf("ALTER VIRTUAL SCHEMA %s SET %s", name, propertyAssignments(changed))
+			_, err := c.Execute(stmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("comment") {
+		err := db.Comment(c, "SCHEMA", d.Get("name").(string), d.Get("comment").(string), "", quoted)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("refresh_trigger") {
+		stmt := fmt.Sprintf("ALTER VIRTUAL SCHEMA %s REFRESH", name)
+		_, err := c.Execute(stmt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
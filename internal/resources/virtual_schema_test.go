@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"testing"
+)
+
+func TestAdapterScript(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"adapter.jdbc_adapter":     `"ADAPTER"."JDBC_ADAPTER"`,
+		`"Adapter"."JdbcAdapter"`:  `"Adapter"."JdbcAdapter"`,
+		"jdbc_adapter":             `"JDBC_ADAPTER"`,
+		`"ADAPTER"."JDBC_ADAPTER"`: `"ADAPTER"."JDBC_ADAPTER"`,
+	}
+
+	for in, expected := range cases {
+		actual, err := adapterScript(in, false)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if actual != expected {
+			t.Errorf("Unexpected Adapter Script for %s: %s", in, actual)
+		}
+	}
+
+	actual, err := adapterScript("Adapter.JdbcAdapter", true)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if actual != `"Adapter"."JdbcAdapter"` {
+		t.Errorf("Unexpected quoted Adapter Script: %s", actual)
+	}
+
+	_, err = adapterScript("a.b.c", false)
+	if err == nil {
+		t.Fatal("Expected error for too many parts")
+	}
+}
+
+func TestSameAdapterScript(t *testing.T) {
+	t.Parallel()
+
+	// As read back from EXA_ALL_VIRTUAL_SCHEMAS
+	state := `"ADAPTER"."JDBC_ADAPTER"`
+	for _, config := range []string{"jdbc_adapter", "adapter.jdbc_adapter", "Adapter.Jdbc_Adapter", `"JDBC_ADAPTER"`} {
+		if !sameAdapterScript(state, config, false) {
+			t.Errorf("Expected %s to match %s", config, state)
+		}
+	}
+
+	if sameAdapterScript(state, "other.jdbc_adapter", false) {
+		t.Error("Expected Scripts in other Schema to differ")
+	}
+	if sameAdapterScript(state, "jdbc_adapter", true) {
+		t.Error("Expected lower case quoted Script to differ")
+	}
+	if !sameAdapterScript(`"Adapter"."JdbcAdapter"`, "JdbcAdapter", true) {
+		t.Error("Expected unqualified quoted Script to match")
+	}
+}
+
+func TestPropertyAssignments(t *testing.T) {
+	t.Parallel()
+
+	actual := propertyAssignments(map[string]interface{}{
+		"sql_dialect":     "HIVE",
+		"CONNECTION_NAME": "it's",
+	})
+	expected := `"CONNECTION_NAME" = 'it''s' "SQL_DIALECT" = 'HIVE'`
+	if actual != expected {
+		t.Fatalf("Unexpected assignments: %s", actual)
+	}
+}
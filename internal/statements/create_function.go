@@ -0,0 +1,56 @@
+package statements
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+)
+
+type FunctionParameter struct {
+	Name string
+	Type string
+}
+
+type CreateFunction struct {
+	Schema     string
+	Name       string
+	Parameters []FunctionParameter
+	Returns    string
+	Body       string
+	Replace    bool
+	Quoted     bool
+}
+
+// Execute creates or replaces Function
+func (s *CreateFunction) Execute(c internal.Conn) error {
+	stmt, err := s.Statement()
+	if err != nil {
+		return err
+	}
+	_, err = c.Execute(stmt)
+	return err
+}
+
+// Statement builds the CREATE statement for the Function
+func (s *CreateFunction) Statement() (string, error) {
+	createPrefix := "CREATE FUNCTION"
+	if s.Replace {
+		createPrefix = "CREATE OR REPLACE FUNCTION"
+	}
+
+	params := make([]string, 0, len(s.Parameters))
+	for _, p := range s.Parameters {
+		if p.Type == "" {
+			return "", fmt.Errorf("missing type of parameter %s", p.Name)
+		}
+		params = append(params, fmt.Sprintf("%s IN %s", quote.Name(p.Name, s.Quoted), p.Type))
+	}
+
+	if s.Returns == "" {
+		return "", fmt.Errorf("missing return type of Function %s", s.Name)
+	}
+
+	return fmt.Sprintf("%s %s (%s) RETURN %s\nIS\n%s", createPrefix, quote.Qualified(s.Schema, s.Name, s.Quoted), strings.Join(params, ", "), s.Returns, s.Body), nil
+}
@@ -0,0 +1,35 @@
+package statements
+
+import (
+	"testing"
+)
+
+func TestCreateFunctionStatement(t *testing.T) {
+	t.Parallel()
+
+	s := CreateFunction{
+		Schema: "math",
+		Name:   "percentage",
+		Parameters: []FunctionParameter{
+			{Name: "fraction", Type: "DECIMAL(18,2)"},
+			{Name: "entirety", Type: "DECIMAL(18,2)"},
+		},
+		Returns: "VARCHAR(10)",
+		Body:    "BEGIN\n  RETURN fraction / entirety;\nEND percentage;",
+		Replace: true,
+	}
+	actual, err := s.Statement()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := "CREATE OR REPLACE FUNCTION \"MATH\".\"PERCENTAGE\" (\"FRACTION\" IN DECIMAL(18,2), \"ENTIRETY\" IN DECIMAL(18,2)) RETURN VARCHAR(10)\nIS\nBEGIN\n  RETURN fraction / entirety;\nEND percentage;"
+	if actual != expected {
+		t.Fatalf("Unexpected statement:\n%s\nexpected:\n%s", actual, expected)
+	}
+
+	s.Returns = ""
+	_, err = s.Statement()
+	if err == nil {
+		t.Fatal("Expected error for missing return type")
+	}
+}
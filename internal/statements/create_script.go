@@ -0,0 +1,145 @@
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+)
+
+const (
+	// ScriptKindScalar is a UDF called once per row
+	ScriptKindScalar = "SCALAR"
+	// ScriptKindSet is a UDF called once per group
+	ScriptKindSet = "SET"
+	// ScriptKindAdapter is an Adapter Script for Virtual Schemas
+	ScriptKindAdapter = "ADAPTER"
+	// ScriptKindScripting is a plain script run via EXECUTE SCRIPT
+	ScriptKindScripting = "SCRIPTING"
+)
+
+var (
+	// ScriptKinds are all supported kinds of Scripts
+	ScriptKinds = []string{ScriptKindScalar, ScriptKindSet, ScriptKindAdapter, ScriptKindScripting}
+
+	plainNameReg = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+type ScriptParameter struct {
+	Name string
+	Type string
+}
+
+type CreateScript struct {
+	Schema   string
+	Name     string
+	Language string
+	Kind     string
+	Input    []ScriptParameter
+	Emits    []ScriptParameter
+	Returns  string
+	Body     string
+	Quoted   bool
+}
+
+// Execute creates or replaces Script
+func (s *CreateScript) Execute(c internal.Conn) error {
+	stmt, err := s.Statement()
+	if err != nil {
+		return err
+	}
+	_, err = c.Execute(stmt)
+	return err
+}
+
+// Statement builds the CREATE OR REPLACE statement for the Script
+func (s *CreateScript) Statement() (string, error) {
+	if !plainNameReg.MatchString(s.Language) {
+		return "", fmt.Errorf("invalid language %s", s.Language)
+	}
+	language := strings.ToUpper(s.Language)
+	name := quote.Qualified(s.Schema, s.Name, s.Quoted)
+
+	switch s.Kind {
+	case ScriptKindScalar, ScriptKindSet:
+		input, err := udfParameters(s.Input)
+		if err != nil {
+			return "", err
+		}
+		var result string
+		switch {
+		case s.Returns != "" && len(s.Emits) != 0:
+			return "", errors.New("only one of returns and emits may be used")
+		case s.Returns != "":
+			result = "RETURNS " + s.Returns
+		case len(s.Emits) != 0:
+			emits, err := udfParameters(s.Emits)
+			if err != nil {
+				return "", err
+			}
+			result = fmt.Sprintf("EMITS (%s)", emits)
+		default:
+			return "", errors.New("need to set one of returns and emits")
+		}
+		return fmt.Sprintf("CREATE OR REPLACE %s %s SCRIPT %s (%s) %s AS\n%s", language, s.Kind, name, input, result, s.Body), nil
+	case ScriptKindAdapter:
+		if len(s.Input) != 0 || len(s.Emits) != 0 || s.Returns != "" {
+			return "", errors.New("adapter scripts do not support input, emits or returns")
+		}
+		return fmt.Sprintf("CREATE OR REPLACE %s ADAPTER SCRIPT %s AS\n%s", language, name, s.Body), nil
+	case ScriptKindScripting:
+		if len(s.Emits) != 0 {
+			return "", errors.New("scripting scripts do not support emits")
+		}
+		input, err := scriptingParameters(s.Input)
+		if err != nil {
+			return "", err
+		}
+		result := ""
+		switch strings.ToUpper(s.Returns) {
+		case "":
+		case "TABLE", "ROWCOUNT":
+			result = " RETURNS " + strings.ToUpper(s.Returns)
+		default:
+			return "", fmt.Errorf("scripting scripts only return TABLE or ROWCOUNT, not %s", s.Returns)
+		}
+		return fmt.Sprintf("CREATE OR REPLACE %s SCRIPT %s (%s)%s AS\n%s", language, name, input, result, s.Body), nil
+	}
+	return "", fmt.Errorf("unknown kind %s", s.Kind)
+}
+
+// udfParameters formats parameters as in a INT, b VARCHAR(10). UDFs see
+// the names exactly as written.
+func udfParameters(params []ScriptParameter) (string, error) {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.Type == "" {
+			return "", fmt.Errorf("missing type of parameter %s", p.Name)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", quote.Identifier(p.Name), p.Type))
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// scriptingParameters formats parameters as in a, ARRAY b. Names end up
+// as variables of the script and thus cannot be delimited.
+func scriptingParameters(params []ScriptParameter) (string, error) {
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if !plainNameReg.MatchString(p.Name) {
+			return "", fmt.Errorf("invalid parameter name %s", p.Name)
+		}
+		switch strings.ToUpper(p.Type) {
+		case "":
+			parts = append(parts, p.Name)
+		case "ARRAY":
+			parts = append(parts, "ARRAY "+p.Name)
+		default:
+			return "", fmt.Errorf("scripting parameter %s can only be of type ARRAY", p.Name)
+		}
+	}
+	return strings.Join(parts, ", "), nil
+}
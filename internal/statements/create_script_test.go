@@ -0,0 +1,98 @@
+package statements
+
+import (
+	"testing"
+)
+
+func TestCreateScriptStatement(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		script   CreateScript
+		expected string
+	}{
+		{
+			name: "scalar returns",
+			script: CreateScript{
+				Schema:   "udf",
+				Name:     "double_it",
+				Language: "python3",
+				Kind:     ScriptKindScalar,
+				Input:    []ScriptParameter{{Name: "x", Type: "INT"}},
+				Returns:  "INT",
+				Body:     "def run(ctx):\n  return ctx.x * 2",
+			},
+			expected: "CREATE OR REPLACE PYTHON3 SCALAR SCRIPT \"UDF\".\"DOUBLE_IT\" (\"x\" INT) RETURNS INT AS\ndef run(ctx):\n  return ctx.x * 2",
+		},
+		{
+			name: "set emits",
+			script: CreateScript{
+				Schema:   "Udf",
+				Name:     "Split",
+				Language: "LUA",
+				Kind:     ScriptKindSet,
+				Input:    []ScriptParameter{{Name: "w", Type: "VARCHAR(100)"}},
+				Emits:    []ScriptParameter{{Name: "a", Type: "VARCHAR(100)"}, {Name: "b", Type: "INT"}},
+				Body:     "function run(ctx) end",
+				Quoted:   true,
+			},
+			expected: "CREATE OR REPLACE LUA SET SCRIPT \"Udf\".\"Split\" (\"w\" VARCHAR(100)) EMITS (\"a\" VARCHAR(100), \"b\" INT) AS\nfunction run(ctx) end",
+		},
+		{
+			name: "adapter",
+			script: CreateScript{
+				Schema:   "adapter",
+				Name:     "jdbc_adapter",
+				Language: "JAVA",
+				Kind:     ScriptKindAdapter,
+				Body:     "%scriptclass com.exasol.adapter.RequestDispatcher;",
+			},
+			expected: "CREATE OR REPLACE JAVA ADAPTER SCRIPT \"ADAPTER\".\"JDBC_ADAPTER\" AS\n%scriptclass com.exasol.adapter.RequestDispatcher;",
+		},
+		{
+			name: "scripting",
+			script: CreateScript{
+				Schema:   "etl",
+				Name:     "load",
+				Language: "LUA",
+				Kind:     ScriptKindScripting,
+				Input:    []ScriptParameter{{Name: "src"}, {Name: "cols", Type: "array"}},
+				Returns:  "table",
+				Body:     "exit()",
+			},
+			expected: "CREATE OR REPLACE LUA SCRIPT \"ETL\".\"LOAD\" (src, ARRAY cols) RETURNS TABLE AS\nexit()",
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := c.script.Statement()
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %s", c.name, err)
+		}
+		if actual != c.expected {
+			t.Errorf("%s: Unexpected statement:\n%s\nexpected:\n%s", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestCreateScriptStatementInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]CreateScript{
+		"missing result": {Language: "LUA", Kind: ScriptKindScalar},
+		"both results":   {Language: "LUA", Kind: ScriptKindScalar, Returns: "INT", Emits: []ScriptParameter{{Name: "a", Type: "INT"}}},
+		"untyped input":  {Language: "LUA", Kind: ScriptKindSet, Returns: "INT", Input: []ScriptParameter{{Name: "a"}}},
+		"adapter input":  {Language: "JAVA", Kind: ScriptKindAdapter, Input: []ScriptParameter{{Name: "a", Type: "INT"}}},
+		"scripting type": {Language: "LUA", Kind: ScriptKindScripting, Input: []ScriptParameter{{Name: "a", Type: "INT"}}},
+		"language":       {Language: "LUA; DROP", Kind: ScriptKindScripting},
+		"kind":           {Language: "LUA", Kind: "AGGREGATE"},
+	}
+
+	for name, s := range cases {
+		_, err := s.Statement()
+		if err == nil {
+			t.Errorf("%s: Expected error", name)
+		}
+	}
+}
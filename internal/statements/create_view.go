@@ -3,7 +3,8 @@ package statements
 import (
 	"fmt"
 
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 type ViewColumn struct {
@@ -18,10 +19,11 @@ type CreateView struct {
 	Subquery string
 	Comment  string
 	Replace  bool
+	Quoted   bool
 }
 
 // Execute creates or replaces View
-func (s *CreateView) Execute(c *exasol.Conn) error {
+func (s *CreateView) Execute(c internal.Conn) error {
 
 	createPrefix := "CREATE VIEW"
 	if s.Replace {
@@ -30,7 +32,7 @@ func (s *CreateView) Execute(c *exasol.Conn) error {
 
 	viewComment := ""
 	if s.Comment != "" {
-		viewComment = fmt.Sprintf(" COMMENT IS '%s'", s.Comment)
+		viewComment = fmt.Sprintf(" COMMENT IS %s", quote.Literal(s.Comment))
 	}
 
 	var colPart string
@@ -38,9 +40,9 @@ func (s *CreateView) Execute(c *exasol.Conn) error {
 		colPart = " ("
 		for i, c := range s.Columns {
 			if c.Comment == "" {
-				colPart += c.Name
+				colPart += quote.Name(c.Name, s.Quoted)
 			} else {
-				colPart += fmt.Sprintf("%s COMMENT IS '%s'", c.Name, c.Comment)
+				colPart += fmt.Sprintf("%s COMMENT IS %s", quote.Name(c.Name, s.Quoted), quote.Literal(c.Comment))
 			}
 			if i+1 != len(s.Columns) {
 				colPart += ", "
@@ -49,7 +51,7 @@ func (s *CreateView) Execute(c *exasol.Conn) error {
 		colPart += ")"
 	}
 
-	stmt := fmt.Sprintf("%s %s%s AS %s%s", createPrefix, s.Name, colPart, s.Subquery, viewComment)
-	_, err := c.Execute(stmt, nil, s.Schema)
+	stmt := fmt.Sprintf("%s %s%s AS %s%s", createPrefix, quote.Qualified(s.Schema, s.Name, s.Quoted), colPart, s.Subquery, viewComment)
+	_, err := c.Execute(stmt, nil, quote.Canonical(s.Schema, s.Quoted))
 	return err
 }
@@ -0,0 +1,20 @@
+package statements
+
+import (
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+)
+
+type DropFunction struct {
+	Schema string
+	Name   string
+	Quoted bool
+}
+
+func (s *DropFunction) Execute(c internal.Conn) error {
+	stmt := fmt.Sprintf("DROP FUNCTION %s", quote.Qualified(s.Schema, s.Name, s.Quoted))
+	_, err := c.Execute(stmt)
+	return err
+}
@@ -0,0 +1,25 @@
+package statements
+
+import (
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+)
+
+type DropScript struct {
+	Schema  string
+	Name    string
+	Adapter bool
+	Quoted  bool
+}
+
+func (s *DropScript) Execute(c internal.Conn) error {
+	kind := "SCRIPT"
+	if s.Adapter {
+		kind = "ADAPTER SCRIPT"
+	}
+	stmt := fmt.Sprintf("DROP %s %s", kind, quote.Qualified(s.Schema, s.Name, s.Quoted))
+	_, err := c.Execute(stmt)
+	return err
+}
@@ -3,16 +3,18 @@ package statements
 import (
 	"fmt"
 
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 type DropView struct {
 	Schema string
 	Name   string
+	Quoted bool
 }
 
-func (s *DropView) Execute(c *exasol.Conn) error {
-	stmt := fmt.Sprintf("DROP VIEW %s", s.Name)
-	_, err := c.Execute(stmt, nil, s.Schema)
+func (s *DropView) Execute(c internal.Conn) error {
+	stmt := fmt.Sprintf("DROP VIEW %s", quote.Qualified(s.Schema, s.Name, s.Quoted))
+	_, err := c.Execute(stmt)
 	return err
 }
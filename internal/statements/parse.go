@@ -0,0 +1,362 @@
+package statements
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// scanner reads the text of CREATE statements as stored by Exasol
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (sc *scanner) skipSpace() {
+	for sc.pos < len(sc.s) && unicode.IsSpace(rune(sc.s[sc.pos])) {
+		sc.pos++
+	}
+}
+
+func (sc *scanner) peek() byte {
+	sc.skipSpace()
+	if sc.pos >= len(sc.s) {
+		return 0
+	}
+	return sc.s[sc.pos]
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '.' || ('0' <= b && b <= '9') || ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z')
+}
+
+// word reads the next keyword or regular identifier in upper case
+func (sc *scanner) word() string {
+	sc.skipSpace()
+	start := sc.pos
+	for sc.pos < len(sc.s) && isWordByte(sc.s[sc.pos]) {
+		sc.pos++
+	}
+	return strings.ToUpper(sc.s[start:sc.pos])
+}
+
+// expect reads the keywords kws
+func (sc *scanner) expect(kws ...string) error {
+	for _, kw := range kws {
+		w := sc.word()
+		if w != kw {
+			return fmt.Errorf("expected %s at %d instead of %s", kw, sc.pos, w)
+		}
+	}
+	return nil
+}
+
+// skipQuoted moves past the quoted string or identifier starting at pos
+func (sc *scanner) skipQuoted() error {
+	q := sc.s[sc.pos]
+	for i := sc.pos + 1; i < len(sc.s); i++ {
+		if sc.s[i] != q {
+			continue
+		}
+		if i+1 < len(sc.s) && sc.s[i+1] == q {
+			i++
+			continue
+		}
+		sc.pos = i + 1
+		return nil
+	}
+	return fmt.Errorf("unterminated %c at %d", q, sc.pos)
+}
+
+// name reads a possibly qualified and delimited name
+func (sc *scanner) name() error {
+	for {
+		if sc.peek() == '"' {
+			err := sc.skipQuoted()
+			if err != nil {
+				return err
+			}
+		} else if sc.word() == "" {
+			return fmt.Errorf("expected name at %d", sc.pos)
+		}
+		if sc.pos >= len(sc.s) || sc.s[sc.pos] != '.' {
+			return nil
+		}
+		sc.pos++
+	}
+}
+
+// group reads the content of the parentheses starting at pos
+func (sc *scanner) group() (string, error) {
+	if sc.peek() != '(' {
+		return "", fmt.Errorf("expected ( at %d", sc.pos)
+	}
+	start := sc.pos + 1
+	depth := 0
+	for sc.pos < len(sc.s) {
+		switch sc.s[sc.pos] {
+		case '"', '\'':
+			err := sc.skipQuoted()
+			if err != nil {
+				return "", err
+			}
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				sc.pos++
+				return sc.s[start : sc.pos-1], nil
+			}
+		}
+		sc.pos++
+	}
+	return "", fmt.Errorf("unterminated ( at %d", start-1)
+}
+
+// until reads up to one of the keywords kws outside of parentheses and
+// returns the text before it. The keyword itself is consumed.
+func (sc *scanner) until(kws ...string) (text, kw string, err error) {
+	start := sc.pos
+	for sc.pos < len(sc.s) {
+		before := sc.pos
+		switch b := sc.s[sc.pos]; {
+		case b == '(':
+			_, err := sc.group()
+			if err != nil {
+				return "", "", err
+			}
+		case b == '"' || b == '\'':
+			err := sc.skipQuoted()
+			if err != nil {
+				return "", "", err
+			}
+		case isWordByte(b):
+			w := sc.word()
+			for _, kw := range kws {
+				if w == kw {
+					return strings.TrimSpace(sc.s[start:before]), kw, nil
+				}
+			}
+		default:
+			sc.pos++
+		}
+	}
+	return "", "", fmt.Errorf("expected one of %s", strings.Join(kws, ", "))
+}
+
+// body returns the rest of the text following the keyword introducing it
+func (sc *scanner) body() string {
+	rest := strings.TrimLeft(sc.s[sc.pos:], " \t")
+	rest = strings.TrimPrefix(rest, "\r")
+	return strings.TrimPrefix(rest, "\n")
+}
+
+// splitList splits list at commas outside of parentheses and quotes
+func splitList(list string) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	sc := &scanner{s: list}
+	parts := []string{}
+	start := 0
+	for sc.pos < len(sc.s) {
+		switch sc.s[sc.pos] {
+		case '"', '\'':
+			err := sc.skipQuoted()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case '(':
+			_, err := sc.group()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case ',':
+			parts = append(parts, strings.TrimSpace(sc.s[start:sc.pos]))
+			start = sc.pos + 1
+		}
+		sc.pos++
+	}
+	return append(parts, strings.TrimSpace(sc.s[start:])), nil
+}
+
+// splitParameter splits a parameter into its name and the rest
+func splitParameter(param string) (name, rest string, err error) {
+	if strings.HasPrefix(param, `"`) {
+		sc := &scanner{s: param}
+		err := sc.skipQuoted()
+		if err != nil {
+			return "", "", err
+		}
+		name = strings.ReplaceAll(param[1:sc.pos-1], `""`, `"`)
+		return name, strings.TrimSpace(param[sc.pos:]), nil
+	}
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return "", "", fmt.Errorf("empty parameter")
+	}
+	return fields[0], strings.TrimSpace(strings.TrimPrefix(param, fields[0])), nil
+}
+
+// ScriptText holds the parts of a CREATE SCRIPT statement
+type ScriptText struct {
+	Input   []ScriptParameter
+	Emits   []ScriptParameter
+	Returns string
+	Body    string
+}
+
+// ParseScriptText splits SCRIPT_TEXT of EXA_ALL_SCRIPTS into its parts
+func ParseScriptText(text string) (ScriptText, error) {
+	st := ScriptText{}
+	sc := &scanner{s: text}
+
+	err := sc.expect("CREATE")
+	if err != nil {
+		return st, err
+	}
+	_, _, err = sc.until("SCRIPT")
+	if err != nil {
+		return st, err
+	}
+	err = sc.name()
+	if err != nil {
+		return st, err
+	}
+
+	if sc.peek() == '(' {
+		list, err := sc.group()
+		if err != nil {
+			return st, err
+		}
+		st.Input, err = scriptParameters(list)
+		if err != nil {
+			return st, err
+		}
+	}
+
+	returns, kw, err := sc.until("RETURNS", "EMITS", "AS")
+	if err != nil {
+		return st, err
+	}
+	if returns != "" {
+		return st, fmt.Errorf("unexpected %s in Script text", returns)
+	}
+	switch kw {
+	case "RETURNS":
+		st.Returns, _, err = sc.until("AS")
+		if err != nil {
+			return st, err
+		}
+	case "EMITS":
+		list, err := sc.group()
+		if err != nil {
+			return st, err
+		}
+		st.Emits, err = scriptParameters(list)
+		if err != nil {
+			return st, err
+		}
+		err = sc.expect("AS")
+		if err != nil {
+			return st, err
+		}
+	}
+
+	st.Body = sc.body()
+	return st, nil
+}
+
+// scriptParameters parses parameters of UDFs like "a" INT and of
+// scripting Scripts like ARRAY a
+func scriptParameters(list string) ([]ScriptParameter, error) {
+	// Dynamic parameters are declared as (...)
+	if strings.TrimSpace(list) == "..." {
+		return nil, nil
+	}
+	parts, err := splitList(list)
+	if err != nil || len(parts) == 0 {
+		return nil, err
+	}
+	params := make([]ScriptParameter, 0, len(parts))
+	for _, part := range parts {
+		name, rest, err := splitParameter(part)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(name, "ARRAY") && rest != "" {
+			name, rest = rest, name
+		}
+		params = append(params, ScriptParameter{
+			Name: name,
+			Type: rest,
+		})
+	}
+	return params, nil
+}
+
+// FunctionText holds the parts of a CREATE FUNCTION statement
+type FunctionText struct {
+	Parameters []FunctionParameter
+	Returns    string
+	Body       string
+}
+
+// ParseFunctionText splits FUNCTION_TEXT of EXA_ALL_FUNCTIONS into its
+// parts
+func ParseFunctionText(text string) (FunctionText, error) {
+	ft := FunctionText{}
+	sc := &scanner{s: text}
+
+	err := sc.expect("CREATE")
+	if err != nil {
+		return ft, err
+	}
+	_, _, err = sc.until("FUNCTION")
+	if err != nil {
+		return ft, err
+	}
+	err = sc.name()
+	if err != nil {
+		return ft, err
+	}
+
+	list, err := sc.group()
+	if err != nil {
+		return ft, err
+	}
+	parts, err := splitList(list)
+	if err != nil {
+		return ft, err
+	}
+	for _, part := range parts {
+		name, rest, err := splitParameter(part)
+		if err != nil {
+			return ft, err
+		}
+		fields := strings.Fields(rest)
+		if len(fields) > 1 && strings.EqualFold(fields[0], "IN") {
+			rest = strings.TrimSpace(rest[len(fields[0]):])
+		}
+		ft.Parameters = append(ft.Parameters, FunctionParameter{
+			Name: name,
+			Type: rest,
+		})
+	}
+
+	err = sc.expect("RETURN")
+	if err != nil {
+		return ft, err
+	}
+	ft.Returns, _, err = sc.until("IS", "AS")
+	if err != nil {
+		return ft, err
+	}
+
+	ft.Body = strings.TrimRight(sc.body(), " \t\r\n/")
+	return ft, nil
+}
@@ -0,0 +1,97 @@
+package statements
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseScriptText(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]ScriptText{
+		"CREATE OR REPLACE PYTHON3 SCALAR SCRIPT \"S\".\"F\" (\"as_of\" DATE, \"amount\" DECIMAL(18,2)) RETURNS VARCHAR(100) UTF8 AS\ndef run(ctx):\n  return 'as'": {
+			Input: []ScriptParameter{
+				{Name: "as_of", Type: "DATE"},
+				{Name: "amount", Type: "DECIMAL(18,2)"},
+			},
+			Returns: "VARCHAR(100) UTF8",
+			Body:    "def run(ctx):\n  return 'as'",
+		},
+		"CREATE LUA SET SCRIPT s.f (\"x\" DOUBLE) EMITS (\"y\" DOUBLE, \"z\" VARCHAR(10)) AS\nfunction run(ctx) end": {
+			Input: []ScriptParameter{
+				{Name: "x", Type: "DOUBLE"},
+			},
+			Emits: []ScriptParameter{
+				{Name: "y", Type: "DOUBLE"},
+				{Name: "z", Type: "VARCHAR(10)"},
+			},
+			Body: "function run(ctx) end",
+		},
+		"CREATE JAVA SET SCRIPT s.f (...) EMITS (...) AS\nclass F {}": {
+			Body: "class F {}",
+		},
+		"CREATE OR REPLACE JAVA ADAPTER SCRIPT \"S\".\"A\" AS\n%jar /buckets/a.jar;": {
+			Body: "%jar /buckets/a.jar;",
+		},
+		"CREATE OR REPLACE LUA SCRIPT \"S\".\"P\" (a, ARRAY b) RETURNS TABLE AS\nreturn 1": {
+			Input: []ScriptParameter{
+				{Name: "a", Type: ""},
+				{Name: "b", Type: "ARRAY"},
+			},
+			Returns: "TABLE",
+			Body:    "return 1",
+		},
+		"CREATE LUA SCRIPT \"S\".\"P\" () AS\nreturn 1": {
+			Body: "return 1",
+		},
+	}
+
+	for text, expected := range tests {
+		actual, err := ParseScriptText(text)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", text, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Unexpected parts of %s: %#v", text, actual)
+		}
+	}
+
+	_, err := ParseScriptText("CREATE LUA SCRIPT \"S\".\"P\" (a")
+	if err == nil {
+		t.Fatal("Expected error for unterminated parameters")
+	}
+}
+
+func TestParseFunctionText(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]FunctionText{
+		"CREATE FUNCTION \"S\".\"F\" (\"X\" IN DECIMAL(18,2), \"y\" IN VARCHAR(10)) RETURN DECIMAL(18,2)\nIS\n  res DOUBLE;\nBEGIN\n  RETURN x;\nEND F;\n/": {
+			Parameters: []FunctionParameter{
+				{Name: "X", Type: "DECIMAL(18,2)"},
+				{Name: "y", Type: "VARCHAR(10)"},
+			},
+			Returns: "DECIMAL(18,2)",
+			Body:    "  res DOUBLE;\nBEGIN\n  RETURN x;\nEND F;",
+		},
+		"CREATE OR REPLACE FUNCTION s.f () RETURN DOUBLE AS\nBEGIN\n  RETURN 1;\nEND f;": {
+			Returns: "DOUBLE",
+			Body:    "BEGIN\n  RETURN 1;\nEND f;",
+		},
+	}
+
+	for text, expected := range tests {
+		actual, err := ParseFunctionText(text)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", text, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Unexpected parts of %s: %#v", text, actual)
+		}
+	}
+
+	_, err := ParseFunctionText("CREATE FUNCTION s.f (x IN DOUBLE) IS BEGIN END;")
+	if err == nil {
+		t.Fatal("Expected error for missing RETURN")
+	}
+}
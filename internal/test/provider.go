@@ -54,7 +54,10 @@ func False(p *schema.Provider, cb func(internal.Conn) (bool, error)) resource.Te
 	return func(state *terraform.State) error {
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		t, err := cb(locked.Conn)
@@ -75,7 +78,10 @@ func True(p *schema.Provider, cb func(internal.Conn) (bool, error)) resource.Tes
 	return func(state *terraform.State) error {
 
 		c := p.Meta().(*exaprovider.Client)
-		locked := c.Lock()
+		locked, err := c.Lock()
+		if err != nil {
+			return err
+		}
 		defer locked.Unlock()
 
 		t, err := cb(locked.Conn)
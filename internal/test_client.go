@@ -35,3 +35,12 @@ func MustCreateTestConf() exasol.ConnConf {
 func MustCreateTestClient() *exaprovider.Client {
 	return exaprovider.NewClient(MustCreateTestConf())
 }
+
+// MustLock tries Lock and fails hard if it does not work
+func MustLock(c *exaprovider.Client) *exaprovider.Locked {
+	locked, err := c.Lock()
+	if err != nil {
+		panic(err)
+	}
+	return locked
+}
@@ -0,0 +1,37 @@
+package internal
+
+// TestConn fakes a connection to Exasol. All statements are recorded.
+type TestConn struct {
+	Statements []string
+	// Errors holds errors to return per statement. Every
+	// error is returned once in order.
+	Errors map[string][]error
+	// Rows holds the result per query
+	Rows map[string][][]interface{}
+}
+
+func (c *TestConn) Commit() error {
+	return c.run("COMMIT")
+}
+
+func (c *TestConn) Execute(sql string, args ...interface{}) (int64, error) {
+	return 0, c.run(sql)
+}
+
+func (c *TestConn) FetchSlice(sql string, args ...interface{}) ([][]interface{}, error) {
+	err := c.run(sql)
+	if err != nil {
+		return nil, err
+	}
+	return c.Rows[sql], nil
+}
+
+func (c *TestConn) run(sql string) error {
+	c.Statements = append(c.Statements, sql)
+	errs := c.Errors[sql]
+	if len(errs) == 0 {
+		return nil
+	}
+	c.Errors[sql] = errs[1:]
+	return errs[0]
+}
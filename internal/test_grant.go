@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+// GrantFuncs are the data functions of a resource granting something
+type GrantFuncs struct {
+	Create func(d Data, c Conn) error
+	Read   func(d Data, c Conn) error
+	Delete func(d Data, c Conn) error
+}
+
+// MustCreateRoles creates Roles and returns the function to drop
+// them again. It has to run while c is still locked.
+func MustCreateRoles(t testing.TB, c Conn, names ...string) func() {
+	t.Helper()
+	for _, name := range names {
+		_, err := c.Execute(fmt.Sprintf("CREATE ROLE %s", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() {
+		for _, name := range names {
+			c.Execute(fmt.Sprintf("DROP ROLE %s", name))
+		}
+	}
+}
+
+// MustGrantAndRevoke runs the lifecycle of the grant in d. After
+// revoking, a read has to detect the missing grant.
+func MustGrantAndRevoke(t testing.TB, c Conn, d *TestData, f GrantFuncs) {
+	t.Helper()
+
+	err := f.Create(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	err = f.Read(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() == "" {
+		t.Fatal("Expected grant to exist")
+	}
+
+	err = f.Delete(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	d.SetId("revoked")
+	err = f.Read(d, c)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if d.Id() != "" {
+		t.Fatal("Expected id reset for revoked grant")
+	}
+}
@@ -0,0 +1,35 @@
+package argument
+
+import (
+	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QuotedIdentifierSchema provides the Schema for switching a Resource
+// to quoted, case-sensitive identifiers
+func QuotedIdentifierSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		ForceNew:    true,
+		Description: "Keep names exactly as written and quote them. Otherwise names are case-insensitive and folded to upper case",
+	}
+}
+
+// QuotedIdentifier reports whether names of d are quoted identifiers
+func QuotedIdentifier(d internal.Data) bool {
+	quoted, _ := d.Get("quoted_identifier").(bool)
+	return quoted
+}
+
+// SuppressCaseDiff suppresses diffs of names which only differ in
+// case, unless quoted identifiers are used
+func SuppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	if QuotedIdentifier(d) {
+		return false
+	}
+	return strings.EqualFold(old, new)
+}
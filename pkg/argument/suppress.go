@@ -0,0 +1,20 @@
+package argument
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SuppressBodyDiff suppresses diffs of code which only differ in
+// surrounding whitespace. Exasol does not keep it as written.
+func SuppressBodyDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// UpperCase is a StateFunc for keywords like privileges or languages,
+// which Exasol reports in upper case
+func UpperCase(v interface{}) string {
+	s, _ := v.(string)
+	return strings.ToUpper(s)
+}
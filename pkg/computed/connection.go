@@ -5,6 +5,7 @@ import (
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
 	"github.com/abergmeier/terraform-provider-exasol/pkg/argument"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 // ReadConnection reads all attributes from Database.
@@ -16,8 +17,8 @@ func ReadConnection(d internal.Data, c internal.Conn) error {
 		return err
 	}
 
-	res, err := c.FetchSlice("SELECT CONNECTION_STRING, USER_NAME, CREATED FROM EXA_DBA_CONNECTIONS WHERE UPPER(CONNECTION_NAME) = UPPER(?)", []interface{}{
-		name,
+	res, err := c.FetchSlice("SELECT CONNECTION_STRING, USER_NAME, CREATED FROM EXA_DBA_CONNECTIONS WHERE CONNECTION_NAME = ?", []interface{}{
+		quote.Canonical(name, argument.QuotedIdentifier(d)),
 	}, "SYS")
 	if err != nil {
 		return err
@@ -5,7 +5,7 @@ import (
 	"strings"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -87,7 +87,9 @@ func PrimaryKeysSchema() *schema.Schema {
 }
 
 // ReadTable reads necessary information of a Table
-func ReadTable(c *exasol.Conn, schema, table string) (*TableReader, error) {
+func ReadTable(c internal.Conn, schema, table string, quoted bool) (*TableReader, error) {
+	schema = quote.Canonical(schema, quoted)
+	table = quote.Canonical(table, quoted)
 	tr := &TableReader{}
 	var err error
 	tcs, err := readTableColumns(c, schema, table)
@@ -111,7 +113,7 @@ func ReadTable(c *exasol.Conn, schema, table string) (*TableReader, error) {
 
 	stmt := `SELECT COLUMN_NAME, COLUMN_TYPE, COLUMN_IS_NULLABLE
 FROM EXA_ALL_COLUMNS
-WHERE UPPER(COLUMN_SCHEMA) = UPPER(?) AND UPPER(COLUMN_TABLE) = UPPER(?)
+WHERE COLUMN_SCHEMA = ? AND COLUMN_TABLE = ?
 ORDER BY COLUMN_ORDINAL_POSITION`
 	res, err := c.FetchSlice(stmt, []interface{}{
 		schema,
@@ -153,8 +155,8 @@ ORDER BY COLUMN_ORDINAL_POSITION`
 	return tr, nil
 }
 
-func readComment(c *exasol.Conn, schema, name string) (string, error) {
-	stmt := "SELECT TABLE_COMMENT FROM EXA_ALL_TABLES WHERE UPPER(TABLE_SCHEMA) = UPPER(?) AND UPPER(TABLE_NAME) = UPPER(?)"
+func readComment(c internal.Conn, schema, name string) (string, error) {
+	stmt := "SELECT TABLE_COMMENT FROM EXA_ALL_TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?"
 	res, err := c.FetchSlice(stmt, []interface{}{
 		schema,
 		name,
@@ -170,8 +172,8 @@ func readComment(c *exasol.Conn, schema, name string) (string, error) {
 	return res[0][0].(string), nil
 }
 
-func readPrimaryKeys(c *exasol.Conn, schema, name string) (map[string]interface{}, error) {
-	stmt := "SELECT COLUMN_NAME, ORDINAL_POSITION FROM EXA_ALL_CONSTRAINT_COLUMNS WHERE UPPER(CONSTRAINT_SCHEMA) = UPPER(?) AND UPPER(CONSTRAINT_TABLE) = UPPER(?) AND CONSTRAINT_TYPE = 'PRIMARY KEY'"
+func readPrimaryKeys(c internal.Conn, schema, name string) (map[string]interface{}, error) {
+	stmt := "SELECT COLUMN_NAME, ORDINAL_POSITION FROM EXA_ALL_CONSTRAINT_COLUMNS WHERE CONSTRAINT_SCHEMA = ? AND CONSTRAINT_TABLE = ? AND CONSTRAINT_TYPE = 'PRIMARY KEY'"
 	cons, err := c.FetchSlice(stmt, []interface{}{
 		schema,
 		name,
@@ -190,8 +192,8 @@ func readPrimaryKeys(c *exasol.Conn, schema, name string) (map[string]interface{
 	return pks, nil
 }
 
-func readForeignKeys(c *exasol.Conn, schema, name string) (map[string]interface{}, error) {
-	stmt := "SELECT COLUMN_NAME, ORDINAL_POSITION FROM EXA_ALL_CONSTRAINT_COLUMNS WHERE UPPER(CONSTRAINT_SCHEMA) = UPPER(?) AND UPPER(CONSTRAINT_TABLE) = UPPER(?) AND CONSTRAINT_TYPE = 'FOREIGN KEY'"
+func readForeignKeys(c internal.Conn, schema, name string) (map[string]interface{}, error) {
+	stmt := "SELECT COLUMN_NAME, ORDINAL_POSITION FROM EXA_ALL_CONSTRAINT_COLUMNS WHERE CONSTRAINT_SCHEMA = ? AND CONSTRAINT_TABLE = ? AND CONSTRAINT_TYPE = 'FOREIGN KEY'"
 	cons, err := c.FetchSlice(stmt, []interface{}{
 		schema,
 		name,
@@ -210,10 +212,10 @@ func readForeignKeys(c *exasol.Conn, schema, name string) (map[string]interface{
 	return fks, nil
 }
 
-func readTableColumns(c *exasol.Conn, schema, table string) (tableColumns, error) {
+func readTableColumns(c internal.Conn, schema, table string) (tableColumns, error) {
 	stmt := `SELECT COLUMN_ORDINAL_POSITION, COLUMN_NAME, COLUMN_TYPE, COLUMN_IS_DISTRIBUTION_KEY, COLUMN_COMMENT
 FROM EXA_ALL_COLUMNS
-WHERE UPPER(COLUMN_SCHEMA) = UPPER(?) AND UPPER(COLUMN_TABLE) = UPPER(?)
+WHERE COLUMN_SCHEMA = ? AND COLUMN_TABLE = ?
 ORDER BY COLUMN_ORDINAL_POSITION`
 
 	res, err := c.FetchSlice(stmt, []interface{}{
@@ -9,7 +9,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/abergmeier/terraform-provider-exasol/internal"
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 var (
@@ -49,8 +49,10 @@ func (v *View) SetColumns(d internal.Data) error {
 	return d.Set("column", columns)
 }
 
-func ReadView(c *exasol.Conn, schema, name string) (*View, error) {
-	stmt := "SELECT VIEW_COMMENT, VIEW_TEXT FROM EXA_ALL_VIEWS WHERE UPPER(VIEW_SCHEMA) = UPPER(?) AND UPPER(VIEW_NAME) = UPPER(?)"
+func ReadView(c internal.Conn, schema, name string, quoted bool) (*View, error) {
+	schema = quote.Canonical(schema, quoted)
+	name = quote.Canonical(name, quoted)
+	stmt := "SELECT VIEW_COMMENT, VIEW_TEXT FROM EXA_ALL_VIEWS WHERE VIEW_SCHEMA = ? AND VIEW_NAME = ?"
 	res, err := c.FetchSlice(stmt, []interface{}{
 		schema,
 		name,
@@ -3,13 +3,18 @@ package db
 import (
 	"fmt"
 
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 // Comment changes the comment on the Database object
-func Comment(c *exasol.Conn, t, objectName, newComment, schema string) error {
+func Comment(c internal.Conn, t, objectName, newComment, schema string, quoted bool) error {
 
-	stmt := fmt.Sprintf("COMMENT ON %s %s IS %s", t, objectName, newComment)
-	_, err := c.Execute(stmt, nil, schema)
+	name := quote.Name(objectName, quoted)
+	if schema != "" {
+		name = quote.Qualified(schema, objectName, quoted)
+	}
+	stmt := fmt.Sprintf("COMMENT ON %s %s IS %s", t, name, quote.Literal(newComment))
+	_, err := c.Execute(stmt)
 	return err
 }
@@ -1,9 +1,9 @@
 package db
 
-import "github.com/grantstreetgroup/go-exasol-client"
+import "github.com/abergmeier/terraform-provider-exasol/internal"
 
 // MustCommit tries commit and fails hard if it does not work
-func MustCommit(c *exasol.Conn) {
+func MustCommit(c internal.Conn) {
 	err := c.Commit()
 	if err != nil {
 		panic(err)
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
+)
+
+// SetConsumerGroup assigns the User or Role to a Consumer Group.
+// An empty group removes the assignment. quoted only applies to name,
+// the group is folded to upper case like an unquoted identifier.
+func SetConsumerGroup(c internal.Conn, t, name, group string, quoted bool) error {
+
+	value := "NULL"
+	if group != "" {
+		value = quote.RegularIdentifier(group)
+	}
+	stmt := fmt.Sprintf("ALTER %s %s SET CONSUMER_GROUP = %s", t, quote.Name(name, quoted), value)
+	_, err := c.Execute(stmt)
+	return err
+}
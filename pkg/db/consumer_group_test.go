@@ -0,0 +1,32 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+)
+
+func TestSetConsumerGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		group    string
+		quoted   bool
+		expected string
+	}{
+		{"Analyst", "high", true, `ALTER USER "Analyst" SET CONSUMER_GROUP = "HIGH"`},
+		{"analyst", "High", false, `ALTER USER "ANALYST" SET CONSUMER_GROUP = "HIGH"`},
+		{"Analyst", "", true, `ALTER USER "Analyst" SET CONSUMER_GROUP = NULL`},
+	}
+	for _, tt := range tests {
+		c := &internal.TestConn{}
+		err := SetConsumerGroup(c, "USER", tt.name, tt.group, tt.quoted)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if len(c.Statements) != 1 || c.Statements[0] != tt.expected {
+			t.Errorf("Unexpected statements for %s (quoted %t): %v (expected %s)", tt.name, tt.quoted, c.Statements, tt.expected)
+		}
+	}
+}
@@ -3,24 +3,24 @@ package db
 import (
 	"fmt"
 
-	"github.com/grantstreetgroup/go-exasol-client"
+	"github.com/abergmeier/terraform-provider-exasol/internal"
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 // Rename changes the name on the Database
-func Rename(c *exasol.Conn, t, old, new, schema string) error {
+func Rename(c internal.Conn, t, old, new, schema string, quoted bool) error {
 
-	stmt := fmt.Sprintf("RENAME %s %s TO %s", t, old, new)
-	var err error
-	if schema == "" {
-		_, err = c.Execute(stmt)
-	} else {
-		_, err = c.Execute(stmt, nil, schema)
+	oldName := quote.Name(old, quoted)
+	if schema != "" {
+		oldName = quote.Qualified(schema, old, quoted)
 	}
+	stmt := fmt.Sprintf("RENAME %s %s TO %s", t, oldName, quote.Name(new, quoted))
+	_, err := c.Execute(stmt)
 	return err
 }
 
 // RenameGlobal changes the global name on the Database
-func RenameGlobal(c *exasol.Conn, t, old, new string) error {
+func RenameGlobal(c internal.Conn, t, old, new string, quoted bool) error {
 
-	return Rename(c, t, old, new, "")
+	return Rename(c, t, old, new, "", quoted)
 }
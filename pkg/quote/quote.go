@@ -0,0 +1,100 @@
+// Package quote escapes identifiers and string literals for
+// statements sent to Exasol. Every value interpolated into
+// generated DDL has to go through this package.
+package quote
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Identifier returns name as delimited identifier. The name is kept
+// exactly as written, so it is matched case-sensitively by Exasol.
+func Identifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// RegularIdentifier returns name as delimited identifier folded to
+// upper case. This is what Exasol does with unquoted identifiers,
+// except that reserved words and special characters stay usable.
+func RegularIdentifier(name string) string {
+	return Identifier(strings.ToUpper(name))
+}
+
+// Literal returns s as string literal
+func Literal(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Canonical returns name the way Exasol stores it in its system
+// tables. Quoted names are kept, all others are folded to upper case.
+func Canonical(name string, quoted bool) string {
+	if quoted {
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
+// Name returns name as identifier. Quoted names are matched
+// case-sensitively, all others are folded to upper case.
+func Name(name string, quoted bool) string {
+	return Identifier(Canonical(name, quoted))
+}
+
+// Qualified returns name qualified by schema
+func Qualified(schema, name string, quoted bool) string {
+	return Name(schema, quoted) + "." + Name(name, quoted)
+}
+
+// SplitQualified splits a possibly qualified name like SCHEMA.NAME or
+// "Schema"."Name" into its parts. Delimited parts are unescaped and
+// reported as quoted.
+func SplitQualified(qn string) (parts []string, quoted bool, err error) {
+	rest := qn
+	for {
+		var part string
+		if strings.HasPrefix(rest, `"`) {
+			quoted = true
+			part, rest, err = splitDelimited(rest)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid qualified name %s: %s", qn, err)
+			}
+		} else {
+			i := strings.Index(rest, ".")
+			if i < 0 {
+				part, rest = rest, ""
+			} else {
+				part, rest = rest[:i], rest[i:]
+			}
+		}
+		if part == "" {
+			return nil, false, fmt.Errorf("invalid qualified name %s: empty part", qn)
+		}
+		parts = append(parts, part)
+		if rest == "" {
+			return parts, quoted, nil
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return nil, false, fmt.Errorf("invalid qualified name %s: expected . after %s", qn, part)
+		}
+		rest = rest[1:]
+	}
+}
+
+// splitDelimited reads a delimited identifier from the start of s
+func splitDelimited(s string) (name, rest string, err error) {
+	b := &strings.Builder{}
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			b.WriteByte('"')
+			i++
+			continue
+		}
+		return b.String(), s[i+1:], nil
+	}
+	return "", "", fmt.Errorf("unterminated identifier %s", s)
+}
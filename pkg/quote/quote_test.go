@@ -0,0 +1,141 @@
+package quote
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"FOO", `"FOO"`},
+		{"SalesData", `"SalesData"`},
+		{`my"name`, `"my""name"`},
+		{`""`, `""""""`},
+		{"it's", `"it's"`},
+		{"straße", `"straße"`},
+		{"日本語", `"日本語"`},
+		{"SELECT", `"SELECT"`},
+		{"table", `"table"`},
+		{"a; DROP USER SYS", `"a; DROP USER SYS"`},
+	}
+	for _, tt := range tests {
+		actual := Identifier(tt.name)
+		if actual != tt.expected {
+			t.Errorf("Unexpected identifier for %s: %s (expected %s)", tt.name, actual, tt.expected)
+		}
+	}
+}
+
+func TestRegularIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"foo", `"FOO"`},
+		{"SalesData", `"SALESDATA"`},
+		{`my"name`, `"MY""NAME"`},
+		{"müller", `"MÜLLER"`},
+		{"select", `"SELECT"`},
+		{"user", `"USER"`},
+	}
+	for _, tt := range tests {
+		actual := RegularIdentifier(tt.name)
+		if actual != tt.expected {
+			t.Errorf("Unexpected identifier for %s: %s (expected %s)", tt.name, actual, tt.expected)
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{"", "''"},
+		{"foo", "'foo'"},
+		{"it's", "'it''s'"},
+		{"''", "''''''"},
+		{`say "hi"`, `'say "hi"'`},
+		{"x' OR '1'='1", "'x'' OR ''1''=''1'"},
+		{"ünïcödé ✓", "'ünïcödé ✓'"},
+		{"line\nbreak", "'line\nbreak'"},
+		{"SELECT", "'SELECT'"},
+	}
+	for _, tt := range tests {
+		actual := Literal(tt.s)
+		if actual != tt.expected {
+			t.Errorf("Unexpected literal for %s: %s (expected %s)", tt.s, actual, tt.expected)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		quoted   bool
+		expected string
+	}{
+		{"SalesData", false, `"SALESDATA"`},
+		{"SalesData", true, `"SalesData"`},
+		{`Sales"Data`, true, `"Sales""Data"`},
+	}
+	for _, tt := range tests {
+		actual := Name(tt.name, tt.quoted)
+		if actual != tt.expected {
+			t.Errorf("Unexpected name for %s (quoted %t): %s (expected %s)", tt.name, tt.quoted, actual, tt.expected)
+		}
+	}
+
+	actual := Qualified("Sales", "Data", true)
+	if actual != `"Sales"."Data"` {
+		t.Errorf("Unexpected qualified name: %s", actual)
+	}
+}
+
+func TestSplitQualified(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		qn       string
+		parts    []string
+		quoted   bool
+		hasError bool
+	}{
+		{qn: "FOO", parts: []string{"FOO"}},
+		{qn: "s.t", parts: []string{"s", "t"}},
+		{qn: `"SalesData"`, parts: []string{"SalesData"}, quoted: true},
+		{qn: `"Sales"."Da.ta"`, parts: []string{"Sales", "Da.ta"}, quoted: true},
+		{qn: `SALES."Da""ta"`, parts: []string{"SALES", `Da"ta`}, quoted: true},
+		{qn: `"Sales`, hasError: true},
+		{qn: `"Sales"x`, hasError: true},
+		{qn: "s.", hasError: true},
+		{qn: "", hasError: true},
+	}
+	for _, tt := range tests {
+		parts, quoted, err := SplitQualified(tt.qn)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("Expected error for %s", tt.qn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %s: %s", tt.qn, err)
+			continue
+		}
+		if quoted != tt.quoted || strings.Join(parts, "|") != strings.Join(tt.parts, "|") {
+			t.Errorf("Unexpected split of %s: %#v (quoted %t)", tt.qn, parts, quoted)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package resource
 import (
 	"fmt"
 	"strings"
+
+	"github.com/abergmeier/terraform-provider-exasol/pkg/quote"
 )
 
 // NewID creates new absolute id for Terraform
@@ -10,6 +12,23 @@ func NewID(schema, name string) string {
 	return fmt.Sprintf("%s.%s", strings.ToUpper(schema), strings.ToUpper(name))
 }
 
+// NewQuotedID creates new absolute id for Terraform. Quoted names
+// stay delimited so the id can be imported again.
+func NewQuotedID(schema, name string, quoted bool) string {
+	if !quoted {
+		return NewID(schema, name)
+	}
+	return quote.Qualified(schema, name, true)
+}
+
+// NewGlobalID creates the id of a global object like a User or Role
+func NewGlobalID(name string, quoted bool) string {
+	if !quoted {
+		return strings.ToUpper(name)
+	}
+	return quote.Identifier(name)
+}
+
 // SplitIDInSchema takes an id prefixed by Schema and extracts the different
 // parts
 func SplitIDInSchema(id string) (schema, name string, err error) {
@@ -24,3 +43,81 @@ func SplitIDInSchema(id string) (schema, name string, err error) {
 	name = parts[1]
 	return
 }
+
+// SplitGlobalID extracts the name of a global object from an import id.
+// Delimited ids like "SalesData" select quoted identifiers.
+func SplitGlobalID(id string) (name string, quoted bool, err error) {
+	parts, quoted, err := quote.SplitQualified(id)
+	if err != nil {
+		return "", false, err
+	}
+	if len(parts) != 1 {
+		return "", false, fmt.Errorf("%s is not a plain name", id)
+	}
+	return parts[0], quoted, nil
+}
+
+// SplitQuotedID extracts schema and name from an import id. Should id
+// not be qualified fallback to schemaDefault. Delimited parts like
+// "Sales"."Data" select quoted identifiers.
+func SplitQuotedID(id, schemaDefault string) (meta DatabaseMeta, quoted bool, err error) {
+	parts, quoted, err := quote.SplitQualified(id)
+	if err != nil {
+		return DatabaseMeta{}, false, err
+	}
+	switch len(parts) {
+	case 1:
+		meta.Schema = schemaDefault
+		meta.ObjectName = parts[0]
+	case 2:
+		meta.Schema = parts[0]
+		meta.ObjectName = parts[1]
+	default:
+		return DatabaseMeta{}, false, fmt.Errorf("%s has too many parts", id)
+	}
+	return meta, quoted, nil
+}
+
+// NewGrantID creates the id of something granted to a User or Role
+// as in grantee:granted
+func NewGrantID(grantee string, quoted bool, granted string) string {
+	return fmt.Sprintf("%s:%s", NewGlobalID(grantee, quoted), granted)
+}
+
+// SplitGrantID extracts grantee and the granted part from an id
+// created by NewGrantID
+func SplitGrantID(id string) (grantee string, quoted bool, granted string, err error) {
+	i := granteeEnd(id)
+	if i >= len(id) || id[i] != ':' {
+		return "", false, "", fmt.Errorf("%s is not of form grantee:granted", id)
+	}
+	granted = id[i+1:]
+	if granted == "" {
+		return "", false, "", fmt.Errorf("%s is missing what is granted", id)
+	}
+	grantee, quoted, err = SplitGlobalID(id[:i])
+	return
+}
+
+// granteeEnd returns the index following the grantee part of id.
+// Delimited grantees may contain colons themselves.
+func granteeEnd(id string) int {
+	if !strings.HasPrefix(id, `"`) {
+		i := strings.Index(id, ":")
+		if i < 0 {
+			return len(id)
+		}
+		return i
+	}
+	for i := 1; i < len(id); i++ {
+		if id[i] != '"' {
+			continue
+		}
+		if i+1 < len(id) && id[i+1] == '"' {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(id)
+}
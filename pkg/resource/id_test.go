@@ -0,0 +1,85 @@
+package resource
+
+import "testing"
+
+func TestSplitGlobalID(t *testing.T) {
+	name, quoted, err := SplitGlobalID("foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if name != "foo" || quoted {
+		t.Fatalf("Unexpected result (expected foo unquoted): %s %t", name, quoted)
+	}
+
+	name, quoted, err = SplitGlobalID(`"Sales""Data"`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if name != `Sales"Data` || !quoted {
+		t.Fatalf("Unexpected result (expected Sales\"Data quoted): %s %t", name, quoted)
+	}
+
+	_, _, err = SplitGlobalID("foo.bar")
+	if err == nil {
+		t.Fatal("Expected error for qualified id")
+	}
+}
+
+func TestSplitQuotedID(t *testing.T) {
+	m, quoted, err := SplitQuotedID(`"Sales"."Data"`, "schemaFoo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if m.Schema != "Sales" || m.ObjectName != "Data" || !quoted {
+		t.Fatalf("Unexpected result (expected Sales.Data quoted): %#v %t", m, quoted)
+	}
+
+	m, quoted, err = SplitQuotedID("tableFoo", "schemaFoo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if m.Schema != "schemaFoo" || m.ObjectName != "tableFoo" || quoted {
+		t.Fatalf("Unexpected result (expected schemaFoo.tableFoo unquoted): %#v %t", m, quoted)
+	}
+
+	id := NewQuotedID("Sales", "Data", true)
+	if id != `"Sales"."Data"` {
+		t.Fatalf("Unexpected id: %s", id)
+	}
+}
+
+func TestSplitGrantID(t *testing.T) {
+	id := NewGrantID("Bob:Admin", true, "CREATE SESSION")
+	if id != `"Bob:Admin":CREATE SESSION` {
+		t.Fatalf("Unexpected id: %s", id)
+	}
+
+	grantee, quoted, granted, err := SplitGrantID(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if grantee != "Bob:Admin" || !quoted || granted != "CREATE SESSION" {
+		t.Fatalf("Unexpected result: %s %t %s", grantee, quoted, granted)
+	}
+
+	grantee, quoted, granted, err = SplitGrantID("bob:create table")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if grantee != "bob" || quoted || granted != "create table" {
+		t.Fatalf("Unexpected result: %s %t %s", grantee, quoted, granted)
+	}
+
+	grantee, _, granted, err = SplitGrantID(`BOB:SELECT ON TABLE "Sales"."A:B"`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if grantee != "BOB" || granted != `SELECT ON TABLE "Sales"."A:B"` {
+		t.Fatalf("Unexpected result: %s %s", grantee, granted)
+	}
+
+	_, _, _, err = SplitGrantID("bob")
+	if err == nil {
+		t.Fatal("Expected error for missing granted part")
+	}
+}
@@ -0,0 +1,24 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// DefaultTimeout is used for every operation not configured
+	// in a timeouts block
+	DefaultTimeout = 20 * time.Minute
+)
+
+// Timeouts makes all operations of a Resource configurable
+// in a timeouts block
+func Timeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(DefaultTimeout),
+		Read:   schema.DefaultTimeout(DefaultTimeout),
+		Update: schema.DefaultTimeout(DefaultTimeout),
+		Delete: schema.DefaultTimeout(DefaultTimeout),
+	}
+}